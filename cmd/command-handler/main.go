@@ -2,23 +2,32 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
-	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
 	"github.com/stevenbode/go-serverless-event-platform/internal/app"
-	"github.com/stevenbode/go-serverless-event-platform/internal/domain"
 	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
 	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
 )
 
-var useCase *app.CreateOrderUseCase
+var (
+	createOrderUseCase  *app.CreateOrderUseCase
+	confirmOrderUseCase *app.ConfirmOrderUseCase
+	cancelOrderUseCase  *app.CancelOrderUseCase
+	shipOrderUseCase    *app.ShipOrderUseCase
+	deliverOrderUseCase *app.DeliverOrderUseCase
+	idempotentExecutor  *app.IdempotentCommandExecutor
+)
 
 func init() {
 	cfg, err := config.LoadDefaultConfig(context.Background())
@@ -27,11 +36,13 @@ func init() {
 	}
 
 	dynamoClient := dynamodb.NewFromConfig(cfg)
-	eventbridgeClient := eventbridge.NewFromConfig(cfg)
 	cloudwatchClient := cloudwatch.NewFromConfig(cfg)
 
 	eventStoreTable := getEnv("EVENT_STORE_TABLE", "event_store")
-	eventBusName := getEnv("EVENT_BUS_NAME", "app-bus")
+	outboxTable := getEnv("OUTBOX_TABLE", "outbox")
+	idempotencyTable := getEnv("IDEMPOTENCY_TABLE", "idempotency")
+	snapshotTable := getEnv("SNAPSHOT_TABLE", "snapshots")
+	snapshotEvery := getEnvInt("SNAPSHOT_EVERY", 0)
 	logLevel := getEnv("LOG_LEVEL", "ERROR")
 
 	logger := observability.NewLoggerWithLevel("", "", observability.LogLevel(logLevel))
@@ -40,78 +51,242 @@ func init() {
 	eventRepo := infra.NewDynamoDBEventRepository(
 		dynamoClient,
 		eventStoreTable,
+		outboxTable,
+		logger,
+	)
+
+	snapshotRepo := infra.NewDynamoDBSnapshotRepository(
+		dynamoClient,
+		snapshotTable,
 		logger,
 	)
 
-	publisher := infra.NewEventBridgePublisher(
-		eventbridgeClient,
-		eventBusName,
+	orderRepo := infra.NewEventSourcedOrderRepositoryWithSnapshots(
+		eventRepo,
+		snapshotRepo,
+		snapshotEvery,
+		logger,
+	)
+
+	idempotencyRepo := infra.NewDynamoDBIdempotencyRepository(
+		dynamoClient,
+		idempotencyTable,
 		logger,
 	)
 
-	useCase = app.NewCreateOrderUseCase(
+	createOrderUseCase = app.NewCreateOrderUseCase(
 		eventRepo,
-		publisher,
+		logger,
+		metrics,
+	)
+
+	confirmOrderUseCase = app.NewConfirmOrderUseCase(eventRepo, orderRepo, logger, metrics)
+	cancelOrderUseCase = app.NewCancelOrderUseCase(eventRepo, orderRepo, logger, metrics)
+	shipOrderUseCase = app.NewShipOrderUseCase(eventRepo, orderRepo, logger, metrics)
+	deliverOrderUseCase = app.NewDeliverOrderUseCase(eventRepo, orderRepo, logger, metrics)
+
+	idempotentExecutor = app.NewIdempotentCommandExecutor(
+		idempotencyRepo,
 		logger,
 		metrics,
 	)
 }
 
 type CreateOrderRequest struct {
-	OrderID    string `json:"order_id,omitempty"`
-	CustomerID string `json:"customer_id"`
-	TotalCents int64  `json:"total_cents"`
+	OrderID    string            `json:"order_id,omitempty"`
+	CustomerID string            `json:"customer_id"`
+	Currency   string            `json:"currency"`
+	Items      []CreateOrderItem `json:"items"`
+}
+
+type CreateOrderItem struct {
+	SKU       string `json:"sku"`
+	Quantity  int64  `json:"quantity"`
+	UnitPrice int64  `json:"unit_price"`
+}
+
+type CancelOrderRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ShipOrderRequest struct {
+	TrackingNumber string `json:"tracking_number"`
 }
 
 func handler(ctx context.Context, req events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
 	correlationID := observability.GetOrGenerateCorrelationID(req.Headers["x-correlation-id"])
 	logger := observability.NewLogger(correlationID, "")
 
+	idempotencyKey := req.Headers["idempotency-key"]
+	if idempotencyKey == "" {
+		return toAPIResponse(route(ctx, req, correlationID, logger), correlationID, false), nil
+	}
+
+	response, replayed, err := idempotentExecutor.Execute(ctx, idempotencyKey, requestHash(req), func(ctx context.Context) app.CommandResponse {
+		return route(ctx, req, correlationID, logger)
+	})
+	if err != nil {
+		logger.Error("idempotency check failed", err, map[string]interface{}{
+			"idempotency_key": idempotencyKey,
+		})
+		return toAPIResponse(errorResponse(err), correlationID, false), nil
+	}
+
+	return toAPIResponse(response, correlationID, replayed), nil
+}
+
+func route(ctx context.Context, req events.APIGatewayV2HTTPRequest, correlationID string, logger *observability.Logger) app.CommandResponse {
+	orderID := req.PathParameters["order_id"]
+
+	switch req.RouteKey {
+	case "POST /orders":
+		return createOrder(ctx, req, correlationID, logger)
+	case "POST /orders/{order_id}/confirm":
+		order, err := confirmOrderUseCase.Execute(ctx, app.ConfirmOrderRequest{OrderID: orderID}, correlationID)
+		return orderResponse(order, err, logger)
+	case "POST /orders/{order_id}/cancel":
+		var cancelReq CancelOrderRequest
+		if err := parseOptionalBody(req.Body, &cancelReq); err != nil {
+			logger.Error("failed to parse request body", err)
+			return invalidBodyResponse()
+		}
+		order, err := cancelOrderUseCase.Execute(ctx, app.CancelOrderRequest{OrderID: orderID, Reason: cancelReq.Reason}, correlationID)
+		return orderResponse(order, err, logger)
+	case "POST /orders/{order_id}/ship":
+		var shipReq ShipOrderRequest
+		if err := parseOptionalBody(req.Body, &shipReq); err != nil {
+			logger.Error("failed to parse request body", err)
+			return invalidBodyResponse()
+		}
+		order, err := shipOrderUseCase.Execute(ctx, app.ShipOrderRequest{OrderID: orderID, TrackingNumber: shipReq.TrackingNumber}, correlationID)
+		return orderResponse(order, err, logger)
+	case "POST /orders/{order_id}/deliver":
+		order, err := deliverOrderUseCase.Execute(ctx, app.DeliverOrderRequest{OrderID: orderID}, correlationID)
+		return orderResponse(order, err, logger)
+	default:
+		return app.CommandResponse{
+			StatusCode: 404,
+			Body:       `{"error":"route not found"}`,
+		}
+	}
+}
+
+func createOrder(ctx context.Context, req events.APIGatewayV2HTTPRequest, correlationID string, logger *observability.Logger) app.CommandResponse {
 	var createReq CreateOrderRequest
 	if err := json.Unmarshal([]byte(req.Body), &createReq); err != nil {
 		logger.Error("failed to parse request body", err)
-		return events.APIGatewayV2HTTPResponse{
-			StatusCode: 400,
-			Body:       `{"error":"invalid request body"}`,
-		}, nil
+		return invalidBodyResponse()
+	}
+
+	items := make([]app.CreateOrderItem, 0, len(createReq.Items))
+	for _, item := range createReq.Items {
+		items = append(items, app.CreateOrderItem{
+			SKU:       item.SKU,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+		})
 	}
 
-	order, err := useCase.Execute(ctx, app.CreateOrderRequest{
+	order, err := createOrderUseCase.Execute(ctx, app.CreateOrderRequest{
 		OrderID:    createReq.OrderID,
 		CustomerID: createReq.CustomerID,
-		TotalCents: createReq.TotalCents,
+		Currency:   createReq.Currency,
+		Items:      items,
 	}, correlationID)
 
 	if err != nil {
-		httpStatus := 500
-		if appErr, ok := err.(*domain.AppError); ok {
-			httpStatus = appErr.HTTPStatus
-		}
 		logger.Error("failed to create order", err)
-		return events.APIGatewayV2HTTPResponse{
-			StatusCode: httpStatus,
-			Body:       fmt.Sprintf(`{"error":"%s"}`, err.Error()),
-			Headers: map[string]string{
-				"X-Correlation-Id": correlationID,
-			},
-		}, nil
+		return errorResponse(err)
 	}
 
 	responseBody, _ := json.Marshal(map[string]interface{}{
 		"order_id":    order.ID,
 		"customer_id": order.CustomerID,
-		"total_cents": order.TotalCents,
+		"items":       orderItemsResponse(order.Items),
+		"currency":    order.Total.Currency,
+		"total_cents": order.Total.Amount,
+		"status":      order.Status,
 		"created_at":  order.CreatedAt.Format("2006-01-02T15:04:05Z"),
 	})
 
-	return events.APIGatewayV2HTTPResponse{
+	return app.CommandResponse{
 		StatusCode: 201,
 		Body:       string(responseBody),
-		Headers: map[string]string{
-			"Content-Type":     "application/json",
-			"X-Correlation-Id": correlationID,
-		},
-	}, nil
+	}
+}
+
+func orderItemsResponse(items []domain.LineItem) []CreateOrderItem {
+	response := make([]CreateOrderItem, 0, len(items))
+	for _, item := range items {
+		response = append(response, CreateOrderItem{
+			SKU:       item.SKU,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice.Amount,
+		})
+	}
+	return response
+}
+
+func orderResponse(order *domain.Order, err error, logger *observability.Logger) app.CommandResponse {
+	if err != nil {
+		logger.Error("failed to change order status", err)
+		return errorResponse(err)
+	}
+
+	responseBody, _ := json.Marshal(map[string]interface{}{
+		"order_id":   order.ID,
+		"status":     order.Status,
+		"version":    order.Version,
+		"updated_at": order.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	})
+
+	return app.CommandResponse{
+		StatusCode: 200,
+		Body:       string(responseBody),
+	}
+}
+
+func parseOptionalBody(body string, v interface{}) error {
+	if body == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(body), v)
+}
+
+func invalidBodyResponse() app.CommandResponse {
+	return app.CommandResponse{
+		StatusCode: 400,
+		Body:       `{"error":"invalid request body"}`,
+	}
+}
+
+func errorResponse(err error) app.CommandResponse {
+	body, _ := json.Marshal(map[string]string{"error": err.Error()})
+	return app.CommandResponse{
+		StatusCode: domain.HTTPStatus(err),
+		Body:       string(body),
+	}
+}
+
+func toAPIResponse(response app.CommandResponse, correlationID string, replayed bool) events.APIGatewayV2HTTPResponse {
+	headers := map[string]string{
+		"Content-Type":     "application/json",
+		"X-Correlation-Id": correlationID,
+	}
+	if replayed {
+		headers["Idempotent-Replayed"] = "true"
+	}
+
+	return events.APIGatewayV2HTTPResponse{
+		StatusCode: response.StatusCode,
+		Body:       response.Body,
+		Headers:    headers,
+	}
+}
+
+func requestHash(req events.APIGatewayV2HTTPRequest) string {
+	hash := sha256.Sum256([]byte(req.RequestContext.HTTP.Method + " " + req.RawPath + "\n" + req.Body))
+	return hex.EncodeToString(hash[:])
 }
 
 func getEnv(key, defaultValue string) string {
@@ -121,6 +296,13 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
 func main() {
 	lambda.Start(handler)
 }
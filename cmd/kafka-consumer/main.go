@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/stevenbode/go-serverless-event-platform/internal/app"
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+// Long-running projection consumer for deployments without EventBridge and
+// Lambda. It routes events through the same projection registry as the
+// projection handler.
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		panic(fmt.Sprintf("failed to load AWS config: %v", err))
+	}
+
+	dynamoClient := dynamodb.NewFromConfig(cfg)
+	cloudwatchClient := cloudwatch.NewFromConfig(cfg)
+
+	brokers := getEnv("KAFKA_BROKERS", "localhost:9092")
+	topic := getEnv("KAFKA_TOPIC", "orders")
+	groupID := getEnv("KAFKA_GROUP_ID", "orders-projection")
+	ordersReadTable := getEnv("ORDERS_READ_TABLE", "orders_read")
+	processedEventsTable := getEnv("PROCESSED_EVENTS_TABLE", "processed_events")
+	consumerName := getEnv("PROJECTION_CONSUMER", "orders-read-model")
+	legacyKeys := getEnv("PROCESSED_EVENTS_LEGACY_KEYS", "false")
+	logLevel := getEnv("LOG_LEVEL", "ERROR")
+
+	logger := observability.NewLoggerWithLevel("", "", observability.LogLevel(logLevel))
+	metrics := observability.NewMetrics(cloudwatchClient, logger, "EventPlatform")
+
+	// Bare event id markers were written by the Lambda projection, so this
+	// consumer only honours them when it takes over that projection's table.
+	processedEventsRepo := infra.NewDynamoDBProcessedEventsRepository(dynamoClient, processedEventsTable, consumerName, logger)
+	if legacyKeys == "true" {
+		processedEventsRepo = infra.NewDynamoDBProcessedEventsRepositoryWithLegacyKeys(dynamoClient, processedEventsTable, consumerName, logger)
+	}
+
+	projectionRepo := infra.NewDynamoDBProjectionRepository(
+		dynamoClient,
+		ordersReadTable,
+		processedEventsRepo,
+		logger,
+	)
+
+	registry, err := app.NewOrderProjectionRegistry(projectionRepo, logger, metrics)
+	if err != nil {
+		logger.Error("failed to register projections", err)
+		os.Exit(1)
+	}
+	projectEventUseCase := app.NewProjectEventUseCase(registry, logger, metrics)
+
+	reader := infra.NewKafkaReader(strings.Split(brokers, ","), topic, groupID)
+	defer reader.Close()
+
+	consumer := infra.NewKafkaConsumer(reader, func(ctx context.Context, event *domain.Event) error {
+		return projectEventUseCase.Execute(ctx, event)
+	}, logger)
+
+	logger.Info("kafka consumer started", map[string]interface{}{
+		"topic":    topic,
+		"group_id": groupID,
+	})
+
+	if err := consumer.Run(ctx); err != nil {
+		logger.Error("kafka consumer stopped", err)
+		os.Exit(1)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/app"
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+// Local development runtime: the command side, the outbox relay and the
+// projection run in one process on in-memory storage, without AWS access.
+type server struct {
+	createOrder   *app.CreateOrderUseCase
+	confirmOrder  *app.ConfirmOrderUseCase
+	cancelOrder   *app.CancelOrderUseCase
+	shipOrder     *app.ShipOrderUseCase
+	deliverOrder  *app.DeliverOrderUseCase
+	relayOutbox   *app.RelayOutboxUseCase
+	readModelRepo infra.ReadModelRepository
+	logger        *observability.Logger
+}
+
+type CreateOrderRequest struct {
+	OrderID    string            `json:"order_id,omitempty"`
+	CustomerID string            `json:"customer_id"`
+	Currency   string            `json:"currency"`
+	Items      []CreateOrderItem `json:"items"`
+}
+
+type CreateOrderItem struct {
+	SKU       string `json:"sku"`
+	Quantity  int64  `json:"quantity"`
+	UnitPrice int64  `json:"unit_price"`
+}
+
+type CancelOrderRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ShipOrderRequest struct {
+	TrackingNumber string `json:"tracking_number"`
+}
+
+func newServer(logger *observability.Logger) *server {
+	eventRepo := infra.NewInMemoryEventRepository()
+	orderRepo := infra.NewEventSourcedOrderRepository(eventRepo, logger)
+	projectionRepo := infra.NewInMemoryProjectionRepository(infra.NewInMemoryProcessedEventsRepository("orders-read-model"))
+
+	registry, err := app.NewOrderProjectionRegistry(projectionRepo, logger, nil)
+	if err != nil {
+		panic(fmt.Sprintf("failed to register projections: %v", err))
+	}
+	projectEvent := app.NewProjectEventUseCase(registry, logger, nil)
+
+	bus := infra.NewInProcessEventBus(logger)
+	bus.Subscribe(projectEvent.Execute)
+
+	return &server{
+		createOrder:   app.NewCreateOrderUseCase(eventRepo, logger, nil),
+		confirmOrder:  app.NewConfirmOrderUseCase(eventRepo, orderRepo, logger, nil),
+		cancelOrder:   app.NewCancelOrderUseCase(eventRepo, orderRepo, logger, nil),
+		shipOrder:     app.NewShipOrderUseCase(eventRepo, orderRepo, logger, nil),
+		deliverOrder:  app.NewDeliverOrderUseCase(eventRepo, orderRepo, logger, nil),
+		relayOutbox:   app.NewRelayOutboxUseCase(eventRepo, bus, logger, nil),
+		readModelRepo: projectionRepo,
+		logger:        logger,
+	}
+}
+
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /orders", s.handleCreateOrder)
+	mux.HandleFunc("POST /orders/{order_id}/confirm", s.handleLifecycle(func(ctx context.Context, orderID, correlationID string, _ []byte) (*domain.Order, error) {
+		return s.confirmOrder.Execute(ctx, app.ConfirmOrderRequest{OrderID: orderID}, correlationID)
+	}))
+	mux.HandleFunc("POST /orders/{order_id}/cancel", s.handleLifecycle(func(ctx context.Context, orderID, correlationID string, body []byte) (*domain.Order, error) {
+		var req CancelOrderRequest
+		if err := parseOptionalBody(body, &req); err != nil {
+			return nil, domain.NewValidationError(err, "invalid request body")
+		}
+		return s.cancelOrder.Execute(ctx, app.CancelOrderRequest{OrderID: orderID, Reason: req.Reason}, correlationID)
+	}))
+	mux.HandleFunc("POST /orders/{order_id}/ship", s.handleLifecycle(func(ctx context.Context, orderID, correlationID string, body []byte) (*domain.Order, error) {
+		var req ShipOrderRequest
+		if err := parseOptionalBody(body, &req); err != nil {
+			return nil, domain.NewValidationError(err, "invalid request body")
+		}
+		return s.shipOrder.Execute(ctx, app.ShipOrderRequest{OrderID: orderID, TrackingNumber: req.TrackingNumber}, correlationID)
+	}))
+	mux.HandleFunc("POST /orders/{order_id}/deliver", s.handleLifecycle(func(ctx context.Context, orderID, correlationID string, _ []byte) (*domain.Order, error) {
+		return s.deliverOrder.Execute(ctx, app.DeliverOrderRequest{OrderID: orderID}, correlationID)
+	}))
+	mux.HandleFunc("GET /orders/{order_id}", s.handleGetOrder)
+	return mux
+}
+
+func (s *server) handleCreateOrder(w http.ResponseWriter, r *http.Request) {
+	correlationID := observability.GetOrGenerateCorrelationID(r.Header.Get("X-Correlation-Id"))
+
+	var req CreateOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, correlationID, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		return
+	}
+
+	items := make([]app.CreateOrderItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, app.CreateOrderItem{SKU: item.SKU, Quantity: item.Quantity, UnitPrice: item.UnitPrice})
+	}
+
+	order, err := s.createOrder.Execute(r.Context(), app.CreateOrderRequest{
+		OrderID:    req.OrderID,
+		CustomerID: req.CustomerID,
+		Currency:   req.Currency,
+		Items:      items,
+	}, correlationID)
+	if err != nil {
+		writeError(w, correlationID, err)
+		return
+	}
+
+	s.relay(r.Context())
+	writeJSON(w, correlationID, http.StatusCreated, orderBody(order))
+}
+
+func (s *server) handleLifecycle(command func(ctx context.Context, orderID, correlationID string, body []byte) (*domain.Order, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		correlationID := observability.GetOrGenerateCorrelationID(r.Header.Get("X-Correlation-Id"))
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeJSON(w, correlationID, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+			return
+		}
+
+		order, err := command(r.Context(), r.PathValue("order_id"), correlationID, body)
+		if err != nil {
+			writeError(w, correlationID, err)
+			return
+		}
+
+		s.relay(r.Context())
+		writeJSON(w, correlationID, http.StatusOK, orderBody(order))
+	}
+}
+
+func (s *server) handleGetOrder(w http.ResponseWriter, r *http.Request) {
+	correlationID := observability.GetOrGenerateCorrelationID(r.Header.Get("X-Correlation-Id"))
+
+	order, err := s.readModelRepo.GetOrder(r.Context(), domain.OrderID(r.PathValue("order_id")))
+	if err != nil {
+		writeError(w, correlationID, err)
+		return
+	}
+	if order == nil {
+		writeJSON(w, correlationID, http.StatusNotFound, map[string]string{"error": "order not found"})
+		return
+	}
+
+	writeJSON(w, correlationID, http.StatusOK, orderBody(order))
+}
+
+// The outbox is drained right after each command, so the read model is
+// up to date when the response is written.
+func (s *server) relay(ctx context.Context) {
+	result, err := s.relayOutbox.Execute(ctx)
+	if err != nil {
+		s.logger.Error("failed to relay outbox", err)
+		return
+	}
+	if result.Failed > 0 {
+		s.logger.Warn("outbox entries left unpublished", map[string]interface{}{
+			"published": result.Published,
+			"failed":    result.Failed,
+		})
+	}
+}
+
+func orderBody(order *domain.Order) map[string]interface{} {
+	items := make([]CreateOrderItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, CreateOrderItem{SKU: item.SKU, Quantity: item.Quantity, UnitPrice: item.UnitPrice.Amount})
+	}
+
+	return map[string]interface{}{
+		"order_id":    order.ID,
+		"customer_id": order.CustomerID,
+		"items":       items,
+		"currency":    order.Total.Currency,
+		"total_cents": order.Total.Amount,
+		"status":      order.Status,
+		"version":     order.Version,
+		"created_at":  order.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		"updated_at":  order.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+func parseOptionalBody(body []byte, v interface{}) error {
+	if len(body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, v)
+}
+
+func writeError(w http.ResponseWriter, correlationID string, err error) {
+	writeJSON(w, correlationID, domain.HTTPStatus(err), map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, correlationID string, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Correlation-Id", correlationID)
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func main() {
+	addr := getEnv("LISTEN_ADDR", ":8080")
+	logLevel := getEnv("LOG_LEVEL", "INFO")
+	logger := observability.NewLoggerWithLevel("", "", observability.LogLevel(logLevel))
+
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           newServer(logger).routes(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("local runtime listening", map[string]interface{}{
+		"addr": addr,
+	})
+
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Error("local runtime stopped", err)
+		os.Exit(1)
+	}
+}
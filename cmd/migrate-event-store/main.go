@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+// Backfills the event store keyed by order_id and aggregate_version from the
+// legacy table keyed by event_id. Commands must not write to either table while
+// it runs; see the cutover steps in the README.
+func main() {
+	sourceTable := flag.String("source-table", os.Getenv("LEGACY_EVENT_STORE_TABLE"), "legacy event store keyed by event_id")
+	targetTable := flag.String("target-table", os.Getenv("EVENT_STORE_TABLE"), "event store keyed by order_id and aggregate_version")
+	dryRun := flag.Bool("dry-run", false, "assign versions without writing anything")
+	flag.Parse()
+
+	if *sourceTable == "" || *targetTable == "" || *sourceTable == *targetTable {
+		fmt.Fprintln(os.Stderr, "-source-table and -target-table are required and must differ")
+		os.Exit(2)
+	}
+
+	logger := observability.NewLoggerWithLevel("", "", observability.LogLevel(getEnv("LOG_LEVEL", "WARN")))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		panic(fmt.Sprintf("failed to load AWS config: %v", err))
+	}
+
+	migration := infra.NewEventStoreMigration(dynamodb.NewFromConfig(cfg), *sourceTable, *targetTable, logger)
+	result, err := migration.Migrate(ctx, *dryRun)
+	fmt.Printf("scanned=%d orders=%d copied=%d skipped=%d dry_run=%t\n", result.Scanned, result.Orders, result.Copied, result.Skipped, *dryRun)
+	if err != nil {
+		logger.Error("migration aborted, rerun to continue", err, map[string]interface{}{
+			"source_table": *sourceTable,
+			"target_table": *targetTable,
+		})
+		os.Exit(1)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
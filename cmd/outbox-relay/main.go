@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/stevenbode/go-serverless-event-platform/internal/app"
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+var (
+	useCase *app.RelayOutboxUseCase
+	logger  *observability.Logger
+)
+
+func init() {
+	cfg, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		panic(fmt.Sprintf("failed to load AWS config: %v", err))
+	}
+
+	dynamoClient := dynamodb.NewFromConfig(cfg)
+	cloudwatchClient := cloudwatch.NewFromConfig(cfg)
+
+	outboxTable := getEnv("OUTBOX_TABLE", "outbox")
+	logLevel := getEnv("LOG_LEVEL", "ERROR")
+
+	eventFormat, err := domain.ParseEventFormat(getEnv("EVENT_FORMAT", string(domain.EventFormatEventBridge)))
+	if err != nil {
+		panic(fmt.Sprintf("invalid EVENT_FORMAT: %v", err))
+	}
+
+	logger = observability.NewLoggerWithLevel("", "", observability.LogLevel(logLevel))
+	metrics := observability.NewMetrics(cloudwatchClient, logger, "EventPlatform")
+
+	outboxRepo := infra.NewDynamoDBOutboxRepository(
+		dynamoClient,
+		outboxTable,
+		logger,
+	)
+
+	publisher, err := newPublisher(cfg, eventFormat)
+	if err != nil {
+		panic(fmt.Sprintf("failed to configure publish targets: %v", err))
+	}
+
+	useCase = app.NewRelayOutboxUseCase(
+		outboxRepo,
+		publisher,
+		logger,
+		metrics,
+	)
+}
+
+// The payload is either a DynamoDB stream batch from the outbox table or a
+// scheduled tick; in both cases the relay drains whatever is pending.
+func handler(ctx context.Context, _ json.RawMessage) error {
+	result, err := useCase.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	if result.Failed > 0 || result.DeadLettered > 0 {
+		logger.Warn("outbox entries left unpublished", map[string]interface{}{
+			"published":     result.Published,
+			"failed":        result.Failed,
+			"dead_lettered": result.DeadLettered,
+		})
+	}
+
+	return nil
+}
+
+// PUBLISH_TARGETS lists the fan-out targets, e.g. "eventbridge,sqs:best-effort".
+func newPublisher(cfg aws.Config, format domain.EventFormat) (*infra.FanOutPublisher, error) {
+	specs, err := infra.ParsePublishTargets(getEnv("PUBLISH_TARGETS", infra.PublishTargetEventBridge))
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]infra.PublishTarget, 0, len(specs))
+	for _, spec := range specs {
+		var publisher infra.EventPublisher
+		switch spec.Kind {
+		case infra.PublishTargetEventBridge:
+			publisher = infra.NewEventBridgePublisherWithFormat(eventbridge.NewFromConfig(cfg), getEnv("EVENT_BUS_NAME", "app-bus"), format, logger)
+		case infra.PublishTargetSQS:
+			queueURL, err := requireEnv("SQS_QUEUE_URL")
+			if err != nil {
+				return nil, err
+			}
+			publisher = infra.NewSQSPublisher(sqs.NewFromConfig(cfg), queueURL, format, logger)
+		case infra.PublishTargetSNS:
+			topicARN, err := requireEnv("SNS_TOPIC_ARN")
+			if err != nil {
+				return nil, err
+			}
+			publisher = infra.NewSNSPublisher(sns.NewFromConfig(cfg), topicARN, format, logger)
+		case infra.PublishTargetHTTP:
+			endpoint, err := requireEnv("HTTP_TARGET_URL")
+			if err != nil {
+				return nil, err
+			}
+			publisher = infra.NewHTTPPublisher(endpoint, format, logger)
+		case infra.PublishTargetKafka:
+			brokers, err := requireEnv("KAFKA_BROKERS")
+			if err != nil {
+				return nil, err
+			}
+			writer := infra.NewKafkaWriter(strings.Split(brokers, ","), getEnv("KAFKA_TOPIC", "orders"))
+			publisher = infra.NewKafkaPublisher(writer, format, logger)
+		}
+
+		targets = append(targets, infra.PublishTarget{
+			Name:      spec.Kind,
+			Publisher: publisher,
+			Policy:    spec.Policy,
+		})
+	}
+
+	return infra.NewFanOutPublisher(targets, logger), nil
+}
+
+func requireEnv(key string) (string, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return "", fmt.Errorf("%s is required", key)
+	}
+	return value, nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func main() {
+	lambda.Start(handler)
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 
@@ -12,12 +11,15 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/stevenbode/go-serverless-event-platform/internal/app"
-	"github.com/stevenbode/go-serverless-event-platform/internal/domain"
 	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
 	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
 )
 
-var useCase *app.ApplyOrderCreatedUseCase
+var (
+	projectEventUseCase *app.ProjectEventUseCase
+	baseLogger          *observability.Logger
+)
 
 func init() {
 	cfg, err := config.LoadDefaultConfig(context.Background())
@@ -30,41 +32,51 @@ func init() {
 
 	ordersReadTable := getEnv("ORDERS_READ_TABLE", "orders_read")
 	processedEventsTable := getEnv("PROCESSED_EVENTS_TABLE", "processed_events")
+	consumer := getEnv("PROJECTION_CONSUMER", "orders-read-model")
+	legacyKeys := getEnv("PROCESSED_EVENTS_LEGACY_KEYS", "true")
 	logLevel := getEnv("LOG_LEVEL", "ERROR")
 
 	logger := observability.NewLoggerWithLevel("", "", observability.LogLevel(logLevel))
+	baseLogger = logger
 	metrics := observability.NewMetrics(cloudwatchClient, logger, "EventPlatform")
 
-	readModelRepo := infra.NewDynamoDBReadModelRepository(
+	// Markers written before they were scoped per consumer are keyed by the
+	// bare event id; keep honouring them until the table TTL has expired them.
+	processedEventsRepo := infra.NewDynamoDBProcessedEventsRepository(dynamoClient, processedEventsTable, consumer, logger)
+	if legacyKeys == "true" {
+		processedEventsRepo = infra.NewDynamoDBProcessedEventsRepositoryWithLegacyKeys(dynamoClient, processedEventsTable, consumer, logger)
+	}
+
+	projectionRepo := infra.NewDynamoDBProjectionRepository(
 		dynamoClient,
 		ordersReadTable,
+		processedEventsRepo,
 		logger,
 	)
 
-	processedEventsRepo := infra.NewDynamoDBProcessedEventsRepository(
-		dynamoClient,
-		processedEventsTable,
-		logger,
-	)
+	registry, err := app.NewOrderProjectionRegistry(projectionRepo, logger, metrics)
+	if err != nil {
+		panic(fmt.Sprintf("failed to register projections: %v", err))
+	}
 
-	useCase = app.NewApplyOrderCreatedUseCase(
-		readModelRepo,
-		processedEventsRepo,
-		logger,
-		metrics,
-	)
+	projectEventUseCase = app.NewProjectEventUseCase(registry, logger, metrics)
 }
 
 func handler(ctx context.Context, event events.EventBridgeEvent) error {
-	var detail app.OrderCreatedEventDetail
-	if err := json.Unmarshal([]byte(event.Detail), &detail); err != nil {
-		return fmt.Errorf("failed to unmarshal event detail: %w", err)
+	canonical, err := domain.DecodeEventDetail(event.Source, event.DetailType, event.Detail)
+	if err != nil {
+		baseLogger.Error("failed to decode event detail", err, map[string]interface{}{
+			"source":      event.Source,
+			"detail_type": event.DetailType,
+		})
+		// Surface the error so the invocation ends up in the ProjectionDLQ.
+		return fmt.Errorf("failed to decode event detail: %w", err)
 	}
 
-	logger := observability.NewLogger(detail.CorrelationID, detail.EventID)
+	logger := observability.NewLogger(canonical.CorrelationID, canonical.EventID)
 
-	if err := useCase.Execute(ctx, detail); err != nil {
-		logger.Error("failed to apply order created event", err, map[string]interface{}{
+	if err := projectEventUseCase.Execute(ctx, canonical); err != nil {
+		logger.Error("failed to apply event", err, map[string]interface{}{
 			"source":      event.Source,
 			"detail_type": event.DetailType,
 		})
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/stevenbode/go-serverless-event-platform/internal/app"
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+// Rebuilds a read model from the event store. Events only go through the
+// projection handlers into the target table: nothing is published, no outbox
+// entries are written and no metrics are sent to CloudWatch. Processed markers
+// are scoped to the replay run, so neither the live projection's markers nor
+// those of earlier replays skip events; a resumed run keeps its run ID in the
+// checkpoint. Rows that are newer than a replayed event are left alone unless
+// -rebuild is set.
+func main() {
+	orderID := flag.String("order-id", "", "replay only this order")
+	eventTypes := flag.String("event-types", "", "comma-separated detail-types to replay, default all")
+	from := flag.String("from", "", "replay events created at or after this RFC3339 time")
+	to := flag.String("to", "", "replay events created before this RFC3339 time")
+	eventStoreTable := flag.String("event-store-table", os.Getenv("EVENT_STORE_TABLE"), "event store to read, default $EVENT_STORE_TABLE")
+	targetTable := flag.String("target-table", getEnv("ORDERS_READ_TABLE", "orders_read"), "read model table to populate")
+	consumer := flag.String("consumer", "", "processed-event consumer name, default replay-<target-table>-<run-id>")
+	rebuild := flag.Bool("rebuild", false, "overwrite rows regardless of their version; stop the live projection first")
+	checkpointFile := flag.String("checkpoint", "replay.checkpoint", "file to resume the scan from and record progress in")
+	rate := flag.Int("rate", 50, "maximum events projected per second, 0 for unlimited")
+	pageSize := flag.Int("page-size", 100, "events per scan page")
+	dryRun := flag.Bool("dry-run", false, "count matching events without writing anything")
+	flag.Parse()
+
+	logger := observability.NewLoggerWithLevel("", "", observability.LogLevel(getEnv("LOG_LEVEL", "WARN")))
+
+	if *eventStoreTable == "" {
+		fmt.Fprintln(os.Stderr, "-event-store-table or EVENT_STORE_TABLE is required")
+		os.Exit(2)
+	}
+
+	// A single order stream is read in one query and cannot be resumed.
+	checkpointSet := false
+	flag.Visit(func(f *flag.Flag) { checkpointSet = checkpointSet || f.Name == "checkpoint" })
+	if *orderID != "" && checkpointSet {
+		fmt.Fprintln(os.Stderr, "-checkpoint cannot be combined with -order-id")
+		os.Exit(2)
+	}
+
+	filter, err := parseFilter(*orderID, *eventTypes, *from, *to)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	runID := time.Now().UTC().Format("20060102T150405Z")
+	var checkpoints infra.CheckpointStore
+	if *orderID == "" {
+		store := infra.NewFileCheckpointStore(*checkpointFile)
+		checkpoint, err := store.LoadCheckpoint(ctx)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		if checkpoint != nil {
+			runID = checkpoint.RunID
+		}
+		checkpoints = store
+	}
+	if *consumer == "" {
+		*consumer = "replay-" + *targetTable + "-" + runID
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		panic(fmt.Sprintf("failed to load AWS config: %v", err))
+	}
+	dynamoClient := dynamodb.NewFromConfig(cfg)
+
+	processedEventsTable := getEnv("PROCESSED_EVENTS_TABLE", "processed_events")
+
+	newProjectionRepo := infra.NewDynamoDBProjectionRepository
+	if *rebuild {
+		newProjectionRepo = infra.NewDynamoDBProjectionRepositoryWithOverwrite
+	}
+	projectionRepo := newProjectionRepo(
+		dynamoClient,
+		*targetTable,
+		infra.NewDynamoDBProcessedEventsRepository(dynamoClient, processedEventsTable, *consumer, logger),
+		logger,
+	)
+	registry, err := app.NewOrderReplayRegistry(projectionRepo, logger)
+	if err != nil {
+		panic(fmt.Sprintf("failed to register projections: %v", err))
+	}
+
+	replay := app.NewReplayEventsUseCase(
+		infra.NewDynamoDBEventRepository(dynamoClient, *eventStoreTable, getEnv("OUTBOX_TABLE", "outbox"), logger),
+		infra.NewDynamoDBEventScanner(dynamoClient, *eventStoreTable, logger),
+		app.NewProjectEventUseCase(registry, logger, nil),
+		checkpoints,
+		logger,
+		nil,
+	)
+
+	result, err := replay.Execute(ctx, app.ReplayOptions{
+		RunID:         runID,
+		Filter:        filter,
+		DryRun:        *dryRun,
+		RatePerSecond: *rate,
+		PageSize:      *pageSize,
+	})
+	fmt.Printf("run_id=%s scanned=%d matched=%d projected=%d duplicates=%d stale=%d orphaned=%d rejected=%d dry_run=%t\n",
+		runID, result.Scanned, result.Matched, result.Projected, result.Duplicates, result.Stale, result.Orphaned, result.Rejected, *dryRun)
+	if err != nil {
+		logger.Error("replay aborted, rerun to resume from the checkpoint", err, map[string]interface{}{
+			"checkpoint": *checkpointFile,
+			"run_id":     runID,
+		})
+		os.Exit(1)
+	}
+	if result.Orphaned > 0 {
+		logger.Warn("skipped events for orders missing from the target table, widen the filter to include their creation", map[string]interface{}{
+			"target_table": *targetTable,
+			"orphaned":     result.Orphaned,
+		})
+	}
+	if result.Stale > 0 {
+		logger.Warn("target table already holds newer rows, rerun with -rebuild or into an empty table to replace them", map[string]interface{}{
+			"target_table": *targetTable,
+			"stale":        result.Stale,
+		})
+	}
+}
+
+func parseFilter(orderID, eventTypes, from, to string) (app.ReplayFilter, error) {
+	filter := app.ReplayFilter{OrderID: domain.OrderID(orderID)}
+	if eventTypes != "" {
+		filter.EventTypes = strings.Split(eventTypes, ",")
+	}
+	if from != "" {
+		parsed, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return filter, fmt.Errorf("invalid -from: %w", err)
+		}
+		filter.From = parsed
+	}
+	if to != "" {
+		parsed, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return filter, fmt.Errorf("invalid -to: %w", err)
+		}
+		filter.To = parsed
+	}
+	return filter, nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
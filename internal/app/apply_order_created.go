@@ -2,97 +2,79 @@ package app
 
 import (
 	"context"
+	"errors"
 	"time"
 
-	"github.com/stevenbode/go-serverless-event-platform/internal/domain"
 	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
 	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
 )
 
 type ApplyOrderCreatedUseCase struct {
-	readModelRepo       infra.ReadModelRepository
-	processedEventsRepo infra.ProcessedEventsRepository
-	logger              *observability.Logger
-	metrics             *observability.Metrics
+	projectionRepo infra.ProjectionRepository
+	logger         *observability.Logger
+	metrics        *observability.Metrics
 }
 
 func NewApplyOrderCreatedUseCase(
-	readModelRepo infra.ReadModelRepository,
-	processedEventsRepo infra.ProcessedEventsRepository,
+	projectionRepo infra.ProjectionRepository,
 	logger *observability.Logger,
 	metrics *observability.Metrics,
 ) *ApplyOrderCreatedUseCase {
 	return &ApplyOrderCreatedUseCase{
-		readModelRepo:       readModelRepo,
-		processedEventsRepo: processedEventsRepo,
-		logger:              logger,
-		metrics:             metrics,
+		projectionRepo: projectionRepo,
+		logger:         logger,
+		metrics:        metrics,
 	}
 }
 
-type OrderCreatedEventDetail struct {
-	EventID       string `json:"event_id"`
-	CorrelationID string `json:"correlation_id"`
-	OrderID       string `json:"order_id"`
-	CustomerID    string `json:"customer_id"`
-	TotalCents    int64  `json:"total_cents"`
-	CreatedAt     string `json:"created_at"`
-}
+type OrderCreatedEventDetail = domain.OrderCreatedEvent
 
 func (uc *ApplyOrderCreatedUseCase) Execute(ctx context.Context, detail OrderCreatedEventDetail) error {
-	start := time.Now()
-	defer func() {
-		if uc.metrics != nil {
-			duration := time.Since(start).Milliseconds()
-			uc.metrics.RecordDuration(ctx, "apply_order_created_duration_ms", float64(duration), map[string]string{
-				"correlation_id": detail.CorrelationID,
-			})
-		}
-	}()
-
-	processed, err := uc.processedEventsRepo.IsProcessed(ctx, detail.EventID)
+	createdAt, err := time.Parse(time.RFC3339, detail.CreatedAt)
 	if err != nil {
 		if uc.metrics != nil {
-			uc.metrics.IncrementCounter(ctx, "apply_order_created_check_errors", map[string]string{
+			uc.metrics.IncrementCounter(ctx, "apply_order_created_parse_errors", map[string]string{
 				"correlation_id": detail.CorrelationID,
 			})
 		}
-		uc.logger.Error("failed to check if event is processed", err, map[string]interface{}{
+		uc.logger.Error("failed to parse created_at", err, map[string]interface{}{
 			"event_id": detail.EventID,
 		})
-		return domain.NewRetriableError(err, "failed to check processed status")
+		return domain.NewNonRetriableError(err, "invalid created_at format")
 	}
 
-	if processed {
-		if uc.metrics != nil {
-			uc.metrics.IncrementCounter(ctx, "apply_order_created_idempotency_hits", map[string]string{
-				"correlation_id": detail.CorrelationID,
-			})
-		}
-		return nil
+	version := detail.AggregateVersion
+	if version == 0 {
+		version = 1
 	}
+	currency := domain.Currency(detail.Currency)
 
-	createdAt, err := time.Parse(time.RFC3339, detail.CreatedAt)
-	if err != nil {
-		if uc.metrics != nil {
-			uc.metrics.IncrementCounter(ctx, "apply_order_created_parse_errors", map[string]string{
-				"correlation_id": detail.CorrelationID,
-			})
-		}
-		uc.logger.Error("failed to parse created_at", err, map[string]interface{}{
-			"event_id": detail.EventID,
+	items := make([]domain.LineItem, 0, len(detail.Items))
+	for _, item := range detail.Items {
+		items = append(items, domain.LineItem{
+			SKU:       item.SKU,
+			Quantity:  item.Quantity,
+			UnitPrice: domain.Money{Amount: item.UnitPriceMinor, Currency: currency},
 		})
-		return domain.NewNonRetriableError(err, "invalid created_at format")
 	}
 
 	order := &domain.Order{
 		ID:         domain.OrderID(detail.OrderID),
 		CustomerID: domain.CustomerID(detail.CustomerID),
-		TotalCents: detail.TotalCents,
+		Items:      items,
+		Total:      domain.Money{Amount: detail.TotalCents, Currency: currency},
+		Status:     domain.OrderStatusCreated,
+		Version:    version,
 		CreatedAt:  createdAt,
+		UpdatedAt:  createdAt,
 	}
 
-	if err := uc.readModelRepo.SaveOrder(ctx, order); err != nil {
+	if err := uc.projectionRepo.SaveOrderAndMarkProcessed(ctx, order, detail.EventID); err != nil {
+		// Duplicates and stale events are acknowledged by the projection middleware.
+		if errors.Is(err, domain.ErrEventAlreadyProcessed) || errors.Is(err, domain.ErrStaleEvent) {
+			return err
+		}
 		if uc.metrics != nil {
 			uc.metrics.IncrementCounter(ctx, "apply_order_created_read_model_errors", map[string]string{
 				"correlation_id": detail.CorrelationID,
@@ -105,23 +87,5 @@ func (uc *ApplyOrderCreatedUseCase) Execute(ctx context.Context, detail OrderCre
 		return domain.NewRetriableError(err, "failed to save order")
 	}
 
-	if err := uc.processedEventsRepo.MarkAsProcessed(ctx, detail.EventID); err != nil {
-		if uc.metrics != nil {
-			uc.metrics.IncrementCounter(ctx, "apply_order_created_mark_processed_errors", map[string]string{
-				"correlation_id": detail.CorrelationID,
-			})
-		}
-		uc.logger.Error("failed to mark event as processed", err, map[string]interface{}{
-			"event_id": detail.EventID,
-		})
-		return domain.NewRetriableError(err, "failed to mark event as processed")
-	}
-
-	if uc.metrics != nil {
-		uc.metrics.IncrementCounter(ctx, "apply_order_created_success", map[string]string{
-			"correlation_id": detail.CorrelationID,
-		})
-	}
-
 	return nil
 }
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/testkit"
+)
+
+func TestApplyOrderCreatedUseCase(t *testing.T) {
+	ctx := context.Background()
+	detail := OrderCreatedEventDetail{
+		EventID:          "event-1",
+		CorrelationID:    "corr-1",
+		OrderID:          "order-1",
+		CustomerID:       "customer-1",
+		Items:            []domain.OrderLineItem{{SKU: "sku-1", Quantity: 2, UnitPriceMinor: 1500}},
+		Currency:         "EUR",
+		TotalCents:       3000,
+		CreatedAt:        time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		AggregateVersion: 1,
+	}
+
+	t.Run("reports a duplicate delivery", func(t *testing.T) {
+		repo := testkit.NewProjectionRepository()
+		uc := NewApplyOrderCreatedUseCase(repo, observability.NewLogger("", ""), nil)
+
+		if err := uc.Execute(ctx, detail); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := uc.Execute(ctx, detail); !errors.Is(err, domain.ErrEventAlreadyProcessed) {
+			t.Fatalf("expected %v, got %v", domain.ErrEventAlreadyProcessed, err)
+		}
+
+		repo.AssertOrderStatus(t, "order-1", domain.OrderStatusCreated)
+		repo.AssertProcessed(t, "event-1")
+		repo.AssertCallCount(t, testkit.MethodSaveOrderAndMarkProcessed, 2)
+		repo.AssertCallCount(t, testkit.MethodMarkAsProcessed, 0)
+	})
+
+	t.Run("failed write leaves the event unprocessed", func(t *testing.T) {
+		repo := testkit.NewProjectionRepository()
+		repo.InjectFailure(testkit.MethodSaveOrderAndMarkProcessed, errors.New("transaction cancelled"), 1)
+		uc := NewApplyOrderCreatedUseCase(repo, observability.NewLogger("", ""), nil)
+
+		if err := uc.Execute(ctx, detail); !domain.IsRetriable(err) {
+			t.Fatalf("expected a retriable error, got %v", err)
+		}
+		if processed, _ := repo.IsProcessed(ctx, "event-1"); processed {
+			t.Fatal("a failed write must not mark the event as processed")
+		}
+		if err := uc.Execute(ctx, detail); err != nil {
+			t.Fatalf("expected the redelivery to succeed, got %v", err)
+		}
+		repo.AssertOrderStatus(t, "order-1", domain.OrderStatusCreated)
+	})
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+type ApplyOrderStatusChangedUseCase struct {
+	projectionRepo infra.ProjectionRepository
+	logger         *observability.Logger
+	metrics        *observability.Metrics
+}
+
+func NewApplyOrderStatusChangedUseCase(
+	projectionRepo infra.ProjectionRepository,
+	logger *observability.Logger,
+	metrics *observability.Metrics,
+) *ApplyOrderStatusChangedUseCase {
+	return &ApplyOrderStatusChangedUseCase{
+		projectionRepo: projectionRepo,
+		logger:         logger,
+		metrics:        metrics,
+	}
+}
+
+type OrderStatusChangedEventDetail struct {
+	EventID          string `json:"event_id"`
+	CorrelationID    string `json:"correlation_id"`
+	OrderID          string `json:"order_id"`
+	Status           string `json:"status"`
+	OccurredAt       string `json:"occurred_at"`
+	AggregateVersion int64  `json:"aggregate_version"`
+}
+
+func (uc *ApplyOrderStatusChangedUseCase) Execute(ctx context.Context, detail OrderStatusChangedEventDetail) error {
+	occurredAt, err := time.Parse(time.RFC3339, detail.OccurredAt)
+	if err != nil {
+		if uc.metrics != nil {
+			uc.metrics.IncrementCounter(ctx, "apply_order_status_changed_parse_errors", map[string]string{
+				"correlation_id": detail.CorrelationID,
+			})
+		}
+		uc.logger.Error("failed to parse occurred_at", err, map[string]interface{}{
+			"event_id": detail.EventID,
+		})
+		return domain.NewNonRetriableError(err, "invalid occurred_at format")
+	}
+
+	order, err := uc.projectionRepo.GetOrder(ctx, domain.OrderID(detail.OrderID))
+	if err != nil {
+		uc.logger.Error("failed to load order from read model", err, map[string]interface{}{
+			"order_id": detail.OrderID,
+			"event_id": detail.EventID,
+		})
+		return domain.NewRetriableError(err, "failed to load order")
+	}
+
+	if order == nil {
+		if uc.metrics != nil {
+			uc.metrics.IncrementCounter(ctx, "apply_order_status_changed_missing_orders", map[string]string{
+				"correlation_id": detail.CorrelationID,
+			})
+		}
+		uc.logger.Warn("order not yet projected", map[string]interface{}{
+			"order_id": detail.OrderID,
+			"event_id": detail.EventID,
+		})
+		return domain.NewRetriableError(domain.ErrOrderNotFound, fmt.Sprintf("order %s not yet projected", detail.OrderID))
+	}
+
+	order.Status = domain.OrderStatus(detail.Status)
+	order.Version = detail.AggregateVersion
+	order.UpdatedAt = occurredAt
+
+	if err := uc.projectionRepo.SaveOrderAndMarkProcessed(ctx, order, detail.EventID); err != nil {
+		// Duplicates and stale events are acknowledged by the projection middleware.
+		if errors.Is(err, domain.ErrEventAlreadyProcessed) || errors.Is(err, domain.ErrStaleEvent) {
+			return err
+		}
+		if uc.metrics != nil {
+			uc.metrics.IncrementCounter(ctx, "apply_order_status_changed_read_model_errors", map[string]string{
+				"correlation_id": detail.CorrelationID,
+			})
+		}
+		uc.logger.Error("failed to save order to read model", err, map[string]interface{}{
+			"order_id": order.ID,
+			"event_id": detail.EventID,
+		})
+		return domain.NewRetriableError(err, "failed to save order")
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+type CancelOrderUseCase struct {
+	orderLifecycleUseCase
+}
+
+func NewCancelOrderUseCase(eventRepo infra.EventRepository, orderRepo infra.OrderRepository, logger *observability.Logger, metrics *observability.Metrics) *CancelOrderUseCase {
+	return &CancelOrderUseCase{
+		orderLifecycleUseCase: orderLifecycleUseCase{
+			eventRepo: eventRepo,
+			orderRepo: orderRepo,
+			logger:    logger,
+			metrics:   metrics,
+		},
+	}
+}
+
+type CancelOrderRequest struct {
+	OrderID string
+	Reason  string
+}
+
+func (uc *CancelOrderUseCase) Execute(ctx context.Context, req CancelOrderRequest, correlationID string) (*domain.Order, error) {
+	return uc.execute(ctx, "cancel_order", domain.OrderID(req.OrderID), correlationID, func(order *domain.Order, eventID string) (*domain.Event, error) {
+		return order.Cancel(eventID, correlationID, req.Reason)
+	})
+}
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"context"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+type ConfirmOrderUseCase struct {
+	orderLifecycleUseCase
+}
+
+func NewConfirmOrderUseCase(eventRepo infra.EventRepository, orderRepo infra.OrderRepository, logger *observability.Logger, metrics *observability.Metrics) *ConfirmOrderUseCase {
+	return &ConfirmOrderUseCase{
+		orderLifecycleUseCase: orderLifecycleUseCase{
+			eventRepo: eventRepo,
+			orderRepo: orderRepo,
+			logger:    logger,
+			metrics:   metrics,
+		},
+	}
+}
+
+type ConfirmOrderRequest struct {
+	OrderID string
+}
+
+func (uc *ConfirmOrderUseCase) Execute(ctx context.Context, req ConfirmOrderRequest, correlationID string) (*domain.Order, error) {
+	return uc.execute(ctx, "confirm_order", domain.OrderID(req.OrderID), correlationID, func(order *domain.Order, eventID string) (*domain.Event, error) {
+		return order.Confirm(eventID, correlationID)
+	})
+}
@@ -2,26 +2,25 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/stevenbode/go-serverless-event-platform/internal/domain"
 	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
 	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
 )
 
 type CreateOrderUseCase struct {
 	eventRepo infra.EventRepository
-	publisher infra.EventPublisher
 	logger    *observability.Logger
 	metrics   *observability.Metrics
 }
 
-func NewCreateOrderUseCase(eventRepo infra.EventRepository, publisher infra.EventPublisher, logger *observability.Logger, metrics *observability.Metrics) *CreateOrderUseCase {
+func NewCreateOrderUseCase(eventRepo infra.EventRepository, logger *observability.Logger, metrics *observability.Metrics) *CreateOrderUseCase {
 	return &CreateOrderUseCase{
 		eventRepo: eventRepo,
-		publisher: publisher,
 		logger:    logger,
 		metrics:   metrics,
 	}
@@ -30,7 +29,14 @@ func NewCreateOrderUseCase(eventRepo infra.EventRepository, publisher infra.Even
 type CreateOrderRequest struct {
 	OrderID    string
 	CustomerID string
-	TotalCents int64
+	Currency   string
+	Items      []CreateOrderItem
+}
+
+type CreateOrderItem struct {
+	SKU       string
+	Quantity  int64
+	UnitPrice int64
 }
 
 func (uc *CreateOrderUseCase) Execute(ctx context.Context, req CreateOrderRequest, correlationID string) (*domain.Order, error) {
@@ -49,7 +55,7 @@ func (uc *CreateOrderUseCase) Execute(ctx context.Context, req CreateOrderReques
 		orderID = domain.OrderID(uuid.New().String())
 	}
 
-	order, err := domain.NewOrder(orderID, domain.CustomerID(req.CustomerID), req.TotalCents)
+	order, err := newOrder(orderID, req)
 	if err != nil {
 		if uc.metrics != nil {
 			uc.metrics.IncrementCounter(ctx, "create_order_validation_errors", map[string]string{
@@ -66,8 +72,8 @@ func (uc *CreateOrderUseCase) Execute(ctx context.Context, req CreateOrderReques
 	eventID := uuid.New().String()
 	event := domain.NewOrderCreatedEvent(eventID, correlationID, order)
 
-	if err := uc.eventRepo.SaveEvent(ctx, event); err != nil {
-		if err == domain.ErrOrderAlreadyExists {
+	if err := uc.eventRepo.SaveEvent(ctx, event, 0); err != nil {
+		if errors.Is(err, domain.ErrOrderAlreadyExists) {
 			if uc.metrics != nil {
 				uc.metrics.IncrementCounter(ctx, "create_order_idempotency_hits", map[string]string{
 					"correlation_id": correlationID,
@@ -77,7 +83,7 @@ func (uc *CreateOrderUseCase) Execute(ctx context.Context, req CreateOrderReques
 				"order_id": orderID,
 				"event_id": eventID,
 			})
-			return nil, domain.NewNonRetriableError(err, "order already exists")
+			return nil, domain.NewConflictError(err, "order already exists")
 		}
 		if uc.metrics != nil {
 			uc.metrics.IncrementCounter(ctx, "create_order_event_store_errors", map[string]string{
@@ -91,19 +97,6 @@ func (uc *CreateOrderUseCase) Execute(ctx context.Context, req CreateOrderReques
 		return nil, domain.NewRetriableError(err, "failed to save event")
 	}
 
-	if err := uc.publisher.PublishEvent(ctx, event); err != nil {
-		if uc.metrics != nil {
-			uc.metrics.IncrementCounter(ctx, "create_order_publish_errors", map[string]string{
-				"correlation_id": correlationID,
-			})
-		}
-		uc.logger.Error("failed to publish event", err, map[string]interface{}{
-			"order_id": orderID,
-			"event_id": eventID,
-		})
-		return nil, domain.NewRetriableError(err, "failed to publish event")
-	}
-
 	if uc.metrics != nil {
 		uc.metrics.IncrementCounter(ctx, "create_order_success", map[string]string{
 			"correlation_id": correlationID,
@@ -112,3 +105,21 @@ func (uc *CreateOrderUseCase) Execute(ctx context.Context, req CreateOrderReques
 
 	return order, nil
 }
+
+func newOrder(orderID domain.OrderID, req CreateOrderRequest) (*domain.Order, error) {
+	currency, err := domain.ParseCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.LineItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, domain.LineItem{
+			SKU:       item.SKU,
+			Quantity:  item.Quantity,
+			UnitPrice: domain.Money{Amount: item.UnitPrice, Currency: currency},
+		})
+	}
+
+	return domain.NewOrder(orderID, domain.CustomerID(req.CustomerID), items)
+}
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"context"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+type DeliverOrderUseCase struct {
+	orderLifecycleUseCase
+}
+
+func NewDeliverOrderUseCase(eventRepo infra.EventRepository, orderRepo infra.OrderRepository, logger *observability.Logger, metrics *observability.Metrics) *DeliverOrderUseCase {
+	return &DeliverOrderUseCase{
+		orderLifecycleUseCase: orderLifecycleUseCase{
+			eventRepo: eventRepo,
+			orderRepo: orderRepo,
+			logger:    logger,
+			metrics:   metrics,
+		},
+	}
+}
+
+type DeliverOrderRequest struct {
+	OrderID string
+}
+
+func (uc *DeliverOrderUseCase) Execute(ctx context.Context, req DeliverOrderRequest, correlationID string) (*domain.Order, error) {
+	return uc.execute(ctx, "deliver_order", domain.OrderID(req.OrderID), correlationID, func(order *domain.Order, eventID string) (*domain.Event, error) {
+		return order.Deliver(eventID, correlationID)
+	})
+}
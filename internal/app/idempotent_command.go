@@ -0,0 +1,85 @@
+package app
+
+import (
+	"context"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+type CommandResponse struct {
+	StatusCode int
+	Body       string
+}
+
+type IdempotentCommandExecutor struct {
+	idempotencyRepo infra.IdempotencyRepository
+	logger          *observability.Logger
+	metrics         *observability.Metrics
+}
+
+func NewIdempotentCommandExecutor(idempotencyRepo infra.IdempotencyRepository, logger *observability.Logger, metrics *observability.Metrics) *IdempotentCommandExecutor {
+	return &IdempotentCommandExecutor{
+		idempotencyRepo: idempotencyRepo,
+		logger:          logger,
+		metrics:         metrics,
+	}
+}
+
+func (e *IdempotentCommandExecutor) Execute(ctx context.Context, key, requestHash string, command func(ctx context.Context) CommandResponse) (CommandResponse, bool, error) {
+	existing, err := e.idempotencyRepo.Acquire(ctx, key, requestHash)
+	if err != nil {
+		if e.metrics != nil {
+			e.metrics.IncrementCounter(ctx, "idempotency_acquire_errors", nil)
+		}
+		e.logger.Error("failed to acquire idempotency key", err, map[string]interface{}{
+			"idempotency_key": key,
+		})
+		return CommandResponse{}, false, domain.NewRetriableError(err, "failed to acquire idempotency key")
+	}
+
+	if existing != nil {
+		if existing.RequestHash != requestHash {
+			if e.metrics != nil {
+				e.metrics.IncrementCounter(ctx, "idempotency_key_reuse", nil)
+			}
+			return CommandResponse{}, false, domain.NewUnprocessableError(domain.ErrIdempotencyKeyReuse, "idempotency key reused with a different request")
+		}
+		if existing.Status != infra.IdempotencyStatusCompleted {
+			if e.metrics != nil {
+				e.metrics.IncrementCounter(ctx, "idempotency_in_flight_conflicts", nil)
+			}
+			return CommandResponse{}, false, domain.NewConflictError(domain.ErrRequestInProgress, "request with this idempotency key is in progress")
+		}
+		if e.metrics != nil {
+			e.metrics.IncrementCounter(ctx, "idempotency_replays", nil)
+		}
+		return CommandResponse{
+			StatusCode: existing.StatusCode,
+			Body:       existing.ResponseBody,
+		}, true, nil
+	}
+
+	response := command(ctx)
+
+	if response.StatusCode >= 500 {
+		if err := e.idempotencyRepo.Release(ctx, key); err != nil {
+			e.logger.Error("failed to release idempotency key", err, map[string]interface{}{
+				"idempotency_key": key,
+			})
+		}
+		return response, false, nil
+	}
+
+	if err := e.idempotencyRepo.Complete(ctx, key, response.StatusCode, response.Body); err != nil {
+		if e.metrics != nil {
+			e.metrics.IncrementCounter(ctx, "idempotency_complete_errors", nil)
+		}
+		e.logger.Error("failed to store idempotent response", err, map[string]interface{}{
+			"idempotency_key": key,
+		})
+	}
+
+	return response, false, nil
+}
@@ -0,0 +1,132 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+type mockIdempotencyRepository struct {
+	records map[string]*infra.IdempotencyRecord
+}
+
+func newMockIdempotencyRepository() *mockIdempotencyRepository {
+	return &mockIdempotencyRepository{
+		records: make(map[string]*infra.IdempotencyRecord),
+	}
+}
+
+func (m *mockIdempotencyRepository) Acquire(ctx context.Context, key, requestHash string) (*infra.IdempotencyRecord, error) {
+	if existing, ok := m.records[key]; ok {
+		copied := *existing
+		return &copied, nil
+	}
+	m.records[key] = &infra.IdempotencyRecord{
+		Key:         key,
+		RequestHash: requestHash,
+		Status:      infra.IdempotencyStatusInProgress,
+	}
+	return nil, nil
+}
+
+func (m *mockIdempotencyRepository) Complete(ctx context.Context, key string, statusCode int, responseBody string) error {
+	record := m.records[key]
+	record.Status = infra.IdempotencyStatusCompleted
+	record.StatusCode = statusCode
+	record.ResponseBody = responseBody
+	return nil
+}
+
+func (m *mockIdempotencyRepository) Release(ctx context.Context, key string) error {
+	delete(m.records, key)
+	return nil
+}
+
+func TestIdempotentCommandExecutor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("replays stored response", func(t *testing.T) {
+		executor := NewIdempotentCommandExecutor(newMockIdempotencyRepository(), observability.NewLogger("", ""), nil)
+		calls := 0
+		command := func(ctx context.Context) CommandResponse {
+			calls++
+			return CommandResponse{StatusCode: 201, Body: `{"order_id":"order-123"}`}
+		}
+
+		first, replayed, err := executor.Execute(ctx, "key-1", "hash-1", command)
+		if err != nil || replayed {
+			t.Fatalf("unexpected first result: replayed=%v err=%v", replayed, err)
+		}
+
+		second, replayed, err := executor.Execute(ctx, "key-1", "hash-1", command)
+		if err != nil {
+			t.Fatalf("unexpected error on replay: %v", err)
+		}
+		if !replayed {
+			t.Errorf("expected second response to be a replay")
+		}
+		if second != first {
+			t.Errorf("expected replayed response %+v, got %+v", first, second)
+		}
+		if calls != 1 {
+			t.Errorf("expected command to run once, ran %d times", calls)
+		}
+	})
+
+	t.Run("rejects in-flight request", func(t *testing.T) {
+		repo := newMockIdempotencyRepository()
+		executor := NewIdempotentCommandExecutor(repo, observability.NewLogger("", ""), nil)
+		repo.Acquire(ctx, "key-1", "hash-1")
+
+		_, _, err := executor.Execute(ctx, "key-1", "hash-1", func(ctx context.Context) CommandResponse {
+			t.Errorf("command must not run while the key is in flight")
+			return CommandResponse{}
+		})
+		if !errors.Is(err, domain.ErrRequestInProgress) {
+			t.Errorf("expected ErrRequestInProgress, got %v", err)
+		}
+		if domain.HTTPStatus(err) != 409 {
+			t.Errorf("expected HTTP status 409, got %d", domain.HTTPStatus(err))
+		}
+	})
+
+	t.Run("rejects key reuse with different request", func(t *testing.T) {
+		executor := NewIdempotentCommandExecutor(newMockIdempotencyRepository(), observability.NewLogger("", ""), nil)
+		executor.Execute(ctx, "key-1", "hash-1", func(ctx context.Context) CommandResponse {
+			return CommandResponse{StatusCode: 201}
+		})
+
+		_, _, err := executor.Execute(ctx, "key-1", "hash-2", func(ctx context.Context) CommandResponse {
+			t.Errorf("command must not run for a reused key")
+			return CommandResponse{}
+		})
+		if domain.HTTPStatus(err) != 422 {
+			t.Errorf("expected HTTP status 422, got %d", domain.HTTPStatus(err))
+		}
+	})
+
+	t.Run("releases key on server error", func(t *testing.T) {
+		executor := NewIdempotentCommandExecutor(newMockIdempotencyRepository(), observability.NewLogger("", ""), nil)
+		calls := 0
+		command := func(ctx context.Context) CommandResponse {
+			calls++
+			if calls == 1 {
+				return CommandResponse{StatusCode: 500}
+			}
+			return CommandResponse{StatusCode: 201}
+		}
+
+		executor.Execute(ctx, "key-1", "hash-1", command)
+		response, replayed, err := executor.Execute(ctx, "key-1", "hash-1", command)
+		if err != nil || replayed {
+			t.Fatalf("expected fresh execution after release: replayed=%v err=%v", replayed, err)
+		}
+		if response.StatusCode != 201 {
+			t.Errorf("expected status 201, got %d", response.StatusCode)
+		}
+	})
+}
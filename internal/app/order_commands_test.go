@@ -0,0 +1,134 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/testkit"
+)
+
+func placedOrder(t *testing.T) *domain.Order {
+	t.Helper()
+	order, err := domain.NewOrder("order-1", "customer-1", []domain.LineItem{
+		{SKU: "sku-1", Quantity: 2, UnitPrice: domain.Money{Amount: 1500, Currency: "EUR"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return order
+}
+
+func TestCreateOrderUseCase(t *testing.T) {
+	req := CreateOrderRequest{
+		OrderID:    "order-1",
+		CustomerID: "customer-1",
+		Currency:   "EUR",
+		Items:      []CreateOrderItem{{SKU: "sku-1", Quantity: 2, UnitPrice: 1500}},
+	}
+
+	t.Run("creates the order", func(t *testing.T) {
+		s := testkit.NewScenario(t)
+		uc := NewCreateOrderUseCase(s.EventRepository(), s.Logger(), nil)
+
+		s.When(func(ctx context.Context) error {
+			_, err := uc.Execute(ctx, req, "corr-1")
+			return err
+		}).Then(domain.NewOrderCreatedEvent("", "corr-1", placedOrder(t)))
+	})
+
+	t.Run("rejects an existing order id", func(t *testing.T) {
+		s := testkit.NewScenario(t)
+		uc := NewCreateOrderUseCase(s.EventRepository(), s.Logger(), nil)
+
+		s.Given(domain.NewOrderCreatedEvent("event-1", "corr-0", placedOrder(t))).
+			When(func(ctx context.Context) error {
+				_, err := uc.Execute(ctx, req, "corr-1")
+				return err
+			}).
+			ThenError(domain.NewConflictError(domain.ErrOrderAlreadyExists, ""))
+	})
+
+	t.Run("rejects an order without items", func(t *testing.T) {
+		s := testkit.NewScenario(t)
+		uc := NewCreateOrderUseCase(s.EventRepository(), s.Logger(), nil)
+		invalid := req
+		invalid.Items = nil
+
+		s.When(func(ctx context.Context) error {
+			_, err := uc.Execute(ctx, invalid, "corr-1")
+			return err
+		}).ThenError(domain.NewValidationError(nil, ""))
+	})
+
+	t.Run("event store outage is retriable", func(t *testing.T) {
+		s := testkit.NewScenario(t)
+		s.EventRepository().InjectFailure(testkit.MethodSaveEvent, errors.New("table unavailable"), 1)
+		uc := NewCreateOrderUseCase(s.EventRepository(), s.Logger(), nil)
+
+		s.When(func(ctx context.Context) error {
+			_, err := uc.Execute(ctx, req, "corr-1")
+			return err
+		}).ThenError(domain.NewRetriableError(nil, ""))
+	})
+}
+
+func TestOrderLifecycleUseCases(t *testing.T) {
+	created := func(t *testing.T) (*domain.Order, *domain.Event) {
+		order := placedOrder(t)
+		return order, domain.NewOrderCreatedEvent("event-1", "corr-0", order)
+	}
+
+	t.Run("confirms a created order", func(t *testing.T) {
+		s := testkit.NewScenario(t)
+		uc := NewConfirmOrderUseCase(s.EventRepository(), s.OrderRepository(), s.Logger(), nil)
+		order, event := created(t)
+		confirmed, _ := order.Confirm("", "corr-1")
+
+		s.Given(event).
+			When(func(ctx context.Context) error {
+				_, err := uc.Execute(ctx, ConfirmOrderRequest{OrderID: "order-1"}, "corr-1")
+				return err
+			}).
+			Then(confirmed)
+	})
+
+	t.Run("ships a confirmed order with its tracking number", func(t *testing.T) {
+		s := testkit.NewScenario(t)
+		uc := NewShipOrderUseCase(s.EventRepository(), s.OrderRepository(), s.Logger(), nil)
+		order, event := created(t)
+		confirmed, _ := order.Confirm("event-2", "corr-0")
+		shipped, _ := order.Ship("", "corr-1", "TRACK-1")
+
+		s.Given(event, confirmed).
+			When(func(ctx context.Context) error {
+				_, err := uc.Execute(ctx, ShipOrderRequest{OrderID: "order-1", TrackingNumber: "TRACK-1"}, "corr-1")
+				return err
+			}).
+			Then(shipped)
+	})
+
+	t.Run("cannot deliver an order that was not shipped", func(t *testing.T) {
+		s := testkit.NewScenario(t)
+		uc := NewDeliverOrderUseCase(s.EventRepository(), s.OrderRepository(), s.Logger(), nil)
+		_, event := created(t)
+
+		s.Given(event).
+			When(func(ctx context.Context) error {
+				_, err := uc.Execute(ctx, DeliverOrderRequest{OrderID: "order-1"}, "corr-1")
+				return err
+			}).
+			ThenError(domain.NewValidationError(domain.ErrInvalidStatusTransition, ""))
+	})
+
+	t.Run("unknown order is not found", func(t *testing.T) {
+		s := testkit.NewScenario(t)
+		uc := NewCancelOrderUseCase(s.EventRepository(), s.OrderRepository(), s.Logger(), nil)
+
+		s.When(func(ctx context.Context) error {
+			_, err := uc.Execute(ctx, CancelOrderRequest{OrderID: "order-1", Reason: "changed my mind"}, "corr-1")
+			return err
+		}).ThenError(domain.NewNotFoundError(domain.ErrOrderNotFound, ""))
+	})
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+type orderTransition func(order *domain.Order, eventID string) (*domain.Event, error)
+
+type orderLifecycleUseCase struct {
+	eventRepo infra.EventRepository
+	orderRepo infra.OrderRepository
+	logger    *observability.Logger
+	metrics   *observability.Metrics
+}
+
+func (uc *orderLifecycleUseCase) execute(ctx context.Context, command string, orderID domain.OrderID, correlationID string, transition orderTransition) (*domain.Order, error) {
+	start := time.Now()
+	defer func() {
+		if uc.metrics != nil {
+			duration := time.Since(start).Milliseconds()
+			uc.metrics.RecordDuration(ctx, command+"_duration_ms", float64(duration), map[string]string{
+				"correlation_id": correlationID,
+			})
+		}
+	}()
+
+	if err := domain.ValidateOrderID(orderID); err != nil {
+		return nil, domain.NewValidationError(err, fmt.Sprintf("invalid order: %v", err))
+	}
+
+	order, err := uc.orderRepo.LoadOrder(ctx, orderID)
+	if err != nil {
+		if uc.metrics != nil {
+			uc.metrics.IncrementCounter(ctx, command+"_load_errors", map[string]string{
+				"correlation_id": correlationID,
+			})
+		}
+		uc.logger.Error("failed to load order", err, map[string]interface{}{
+			"order_id": orderID,
+		})
+		return nil, domain.NewRetriableError(err, "failed to load order")
+	}
+
+	if order == nil {
+		return nil, domain.NewNotFoundError(domain.ErrOrderNotFound, "order not found")
+	}
+
+	expectedVersion := order.Version
+	eventID := uuid.New().String()
+
+	event, err := transition(order, eventID)
+	if err != nil {
+		if uc.metrics != nil {
+			uc.metrics.IncrementCounter(ctx, command+"_validation_errors", map[string]string{
+				"correlation_id": correlationID,
+			})
+		}
+		uc.logger.Warn("order transition rejected", map[string]interface{}{
+			"order_id": orderID,
+			"status":   order.Status,
+			"error":    err.Error(),
+		})
+		return nil, domain.NewValidationError(err, fmt.Sprintf("invalid order transition: %v", err))
+	}
+
+	if err := uc.eventRepo.SaveEvent(ctx, event, expectedVersion); err != nil {
+		if errors.Is(err, domain.ErrConcurrencyConflict) {
+			if uc.metrics != nil {
+				uc.metrics.IncrementCounter(ctx, command+"_concurrency_conflicts", map[string]string{
+					"correlation_id": correlationID,
+				})
+			}
+			uc.logger.Warn("concurrent write to order stream", map[string]interface{}{
+				"order_id":         orderID,
+				"event_id":         eventID,
+				"expected_version": expectedVersion,
+			})
+			return nil, domain.NewConflictError(err, "order was modified concurrently")
+		}
+		if uc.metrics != nil {
+			uc.metrics.IncrementCounter(ctx, command+"_event_store_errors", map[string]string{
+				"correlation_id": correlationID,
+			})
+		}
+		uc.logger.Error("failed to save event", err, map[string]interface{}{
+			"order_id": orderID,
+			"event_id": eventID,
+		})
+		return nil, domain.NewRetriableError(err, "failed to save event")
+	}
+
+	if uc.metrics != nil {
+		uc.metrics.IncrementCounter(ctx, command+"_success", map[string]string{
+			"correlation_id": correlationID,
+		})
+	}
+
+	return order, nil
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+type ProjectEventUseCase struct {
+	registry *ProjectionRegistry
+	logger   *observability.Logger
+	metrics  *observability.Metrics
+}
+
+func NewProjectEventUseCase(registry *ProjectionRegistry, logger *observability.Logger, metrics *observability.Metrics) *ProjectEventUseCase {
+	return &ProjectEventUseCase{
+		registry: registry,
+		logger:   logger,
+		metrics:  metrics,
+	}
+}
+
+// NewOrderProjectionRegistry routes order events to the orders read model,
+// wrapped in the shared metrics and idempotency middleware.
+func NewOrderProjectionRegistry(projectionRepo infra.ProjectionRepository, logger *observability.Logger, metrics *observability.Metrics) (*ProjectionRegistry, error) {
+	registry := NewProjectionRegistry()
+	registry.Use(
+		ProjectionMetrics(metrics),
+		ProjectionIdempotency(projectionRepo, logger, metrics),
+	)
+	if err := registerOrderProjections(registry, projectionRepo, logger, metrics); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// NewOrderReplayRegistry routes order events like NewOrderProjectionRegistry
+// but hands duplicates and stale events back to the caller instead of
+// acknowledging them, so a replay can report them.
+func NewOrderReplayRegistry(projectionRepo infra.ProjectionRepository, logger *observability.Logger) (*ProjectionRegistry, error) {
+	registry := NewProjectionRegistry()
+	registry.Use(SkipProcessedEvents(projectionRepo, logger, nil))
+	if err := registerOrderProjections(registry, projectionRepo, logger, nil); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+func registerOrderProjections(registry *ProjectionRegistry, projectionRepo infra.ProjectionRepository, logger *observability.Logger, metrics *observability.Metrics) error {
+	orderCreated := NewApplyOrderCreatedUseCase(projectionRepo, logger, metrics)
+	if err := registry.Register(domain.EventSourceOrders, domain.EventTypeOrderCreated, domain.EventVersionV2, func(ctx context.Context, event *domain.Event) error {
+		var detail OrderCreatedEventDetail
+		if err := json.Unmarshal(event.Data, &detail); err != nil {
+			return domain.NewNonRetriableError(err, "invalid order created detail")
+		}
+		return orderCreated.Execute(ctx, detail)
+	}); err != nil {
+		return err
+	}
+
+	orderStatusChanged := NewApplyOrderStatusChangedUseCase(projectionRepo, logger, metrics)
+	for _, eventType := range []string{domain.EventTypeOrderConfirmed, domain.EventTypeOrderCancelled, domain.EventTypeOrderShipped, domain.EventTypeOrderDelivered} {
+		if err := registry.Register(domain.EventSourceOrders, eventType, domain.EventVersionV1, func(ctx context.Context, event *domain.Event) error {
+			var detail OrderStatusChangedEventDetail
+			if err := json.Unmarshal(event.Data, &detail); err != nil {
+				return domain.NewNonRetriableError(err, "invalid order status changed detail")
+			}
+			return orderStatusChanged.Execute(ctx, detail)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Execute routes the upcast event to the handler registered for its source,
+// type and canonical version. Events nobody projects are acknowledged and
+// counted rather than failed, so new event types can be published before
+// their projections exist.
+func (uc *ProjectEventUseCase) Execute(ctx context.Context, event *domain.Event) error {
+	if err := domain.ValidateEvent(event); err != nil {
+		if errors.Is(err, domain.ErrUnregisteredEventType) {
+			return uc.skipUnhandled(ctx, event)
+		}
+		return domain.NewNonRetriableError(err, "event payload rejected by schema registry")
+	}
+	canonical, err := domain.UpcastEvent(event)
+	if err != nil {
+		return domain.NewNonRetriableError(err, "invalid event detail")
+	}
+
+	handler, ok := uc.registry.Lookup(canonical.Source, canonical.EventType, canonical.Version)
+	if !ok {
+		return uc.skipUnhandled(ctx, canonical)
+	}
+	return handler(ctx, canonical)
+}
+
+func (uc *ProjectEventUseCase) skipUnhandled(ctx context.Context, event *domain.Event) error {
+	if uc.metrics != nil {
+		uc.metrics.IncrementCounter(ctx, "projection_unhandled_events", map[string]string{
+			"event_type":     event.EventType,
+			"correlation_id": event.CorrelationID,
+		})
+	}
+	uc.logger.Warn("no projection handler for event", map[string]interface{}{
+		"event_id":   event.EventID,
+		"event_type": domain.EventTypeKey{Source: event.Source, EventType: event.EventType, Version: event.Version}.String(),
+	})
+	return nil
+}
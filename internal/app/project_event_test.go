@@ -0,0 +1,152 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/testkit"
+)
+
+// racingProjectionRepository reports every event as unprocessed, as if another
+// delivery committed between the IsProcessed check and the write.
+type racingProjectionRepository struct {
+	*testkit.ProjectionRepository
+}
+
+func (r racingProjectionRepository) IsProcessed(ctx context.Context, eventID string) (bool, error) {
+	return false, nil
+}
+
+func newOrderProjection(t *testing.T, repo infra.ProjectionRepository) *ProjectEventUseCase {
+	t.Helper()
+	logger := observability.NewLogger("", "")
+	registry, err := NewOrderProjectionRegistry(repo, logger, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return NewProjectEventUseCase(registry, logger, nil)
+}
+
+func TestProjectEventUseCase(t *testing.T) {
+	ctx := context.Background()
+	order, err := domain.NewOrder("order-1", "customer-1", []domain.LineItem{{SKU: "sku-1", Quantity: 2, UnitPrice: domain.Money{Amount: 1500, Currency: "EUR"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created := domain.NewOrderCreatedEvent("event-1", "corr-1", order)
+	confirmed, err := order.Confirm("event-2", "corr-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	shipped, err := order.Ship("event-3", "corr-1", "track-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	refunded := *confirmed
+	refunded.EventID = "event-4"
+	refunded.EventType = "OrderRefunded"
+
+	tests := []struct {
+		name        string
+		deliveries  []*domain.Event
+		wantStatus  domain.OrderStatus
+		wantVersion int64
+		wantWrites  int
+	}{
+		{name: "applies each event once", deliveries: []*domain.Event{created, created}, wantStatus: domain.OrderStatusCreated, wantVersion: 1, wantWrites: 1},
+		{name: "in order", deliveries: []*domain.Event{created, confirmed, shipped}, wantStatus: domain.OrderStatusShipped, wantVersion: 3, wantWrites: 3},
+		{name: "older event arriving late is ignored", deliveries: []*domain.Event{created, shipped, confirmed}, wantStatus: domain.OrderStatusShipped, wantVersion: 3, wantWrites: 3},
+		{name: "unknown event type is acknowledged", deliveries: []*domain.Event{created, &refunded}, wantStatus: domain.OrderStatusCreated, wantVersion: 1, wantWrites: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := testkit.NewProjectionRepository()
+			uc := newOrderProjection(t, repo)
+
+			for _, event := range tt.deliveries {
+				if err := uc.Execute(ctx, event); err != nil {
+					t.Fatalf("%s: expected the delivery to be acknowledged, got %v", event.EventID, err)
+				}
+			}
+
+			repo.AssertOrderStatus(t, "order-1", tt.wantStatus)
+			repo.AssertCallCount(t, testkit.MethodSaveOrderAndMarkProcessed, tt.wantWrites)
+			if got, _ := repo.GetOrder(ctx, "order-1"); got == nil || got.Version != tt.wantVersion {
+				t.Errorf("expected the read model at version %d, got %+v", tt.wantVersion, got)
+			}
+		})
+	}
+
+	t.Run("concurrent duplicate is an idempotency hit", func(t *testing.T) {
+		repo := racingProjectionRepository{testkit.NewProjectionRepository()}
+		if err := repo.MarkAsProcessed(ctx, created.EventID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := newOrderProjection(t, repo).Execute(ctx, created); err != nil {
+			t.Fatalf("expected the duplicate to be acknowledged, got %v", err)
+		}
+		if got, _ := repo.GetOrder(ctx, "order-1"); got != nil {
+			t.Errorf("the duplicate must not write the read model")
+		}
+	})
+
+	t.Run("failed idempotency check is retriable", func(t *testing.T) {
+		repo := testkit.NewProjectionRepository()
+		repo.InjectFailure(testkit.MethodIsProcessed, errors.New("table unavailable"), 1)
+
+		if err := newOrderProjection(t, repo).Execute(ctx, created); !domain.IsRetriable(err) {
+			t.Fatalf("expected a retriable error, got %v", err)
+		}
+		repo.AssertCallCount(t, testkit.MethodSaveOrderAndMarkProcessed, 0)
+	})
+}
+
+func TestProjectionRegistry(t *testing.T) {
+	ctx := context.Background()
+	event := &domain.Event{EventID: "event-1", Source: domain.EventSourceOrders, EventType: domain.EventTypeOrderConfirmed, Version: domain.EventVersionV1}
+
+	var calls []string
+	trace := func(name string) ProjectionMiddleware {
+		return func(next ProjectionHandler) ProjectionHandler {
+			return func(ctx context.Context, event *domain.Event) error {
+				calls = append(calls, name)
+				return next(ctx, event)
+			}
+		}
+	}
+
+	registry := NewProjectionRegistry()
+	handler := func(ctx context.Context, event *domain.Event) error {
+		calls = append(calls, "handler")
+		return nil
+	}
+	if err := registry.Register(event.Source, event.EventType, event.Version, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	registry.Use(trace("outer"), trace("inner"))
+
+	if err := registry.Register(event.Source, event.EventType, event.Version, handler); !errors.Is(err, ErrProjectionHandlerExists) {
+		t.Errorf("expected %v, got %v", ErrProjectionHandlerExists, err)
+	}
+	if _, ok := registry.Lookup(event.Source, event.EventType, domain.EventVersionV2); ok {
+		t.Errorf("expected no handler for another version")
+	}
+
+	wrapped, ok := registry.Lookup(event.Source, event.EventType, event.Version)
+	if !ok {
+		t.Fatal("expected the registered handler")
+	}
+	if err := wrapped(ctx, event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fmt.Sprint(calls); got != "[outer inner handler]" {
+		t.Errorf("expected middleware to run outermost first, got %s", got)
+	}
+}
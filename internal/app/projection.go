@@ -0,0 +1,146 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+var ErrProjectionHandlerExists = errors.New("projection handler already registered")
+
+// ProjectionHandler applies a canonical (upcast) event to a projection. It
+// reports duplicates with domain.ErrEventAlreadyProcessed and out-of-order
+// deliveries with domain.ErrStaleEvent.
+type ProjectionHandler func(ctx context.Context, event *domain.Event) error
+
+type ProjectionMiddleware func(next ProjectionHandler) ProjectionHandler
+
+type ProjectionRegistry struct {
+	handlers   map[domain.EventTypeKey]ProjectionHandler
+	middleware []ProjectionMiddleware
+}
+
+func NewProjectionRegistry() *ProjectionRegistry {
+	return &ProjectionRegistry{
+		handlers: make(map[domain.EventTypeKey]ProjectionHandler),
+	}
+}
+
+// Use wraps every handler, including ones registered earlier. The first
+// middleware is the outermost.
+func (r *ProjectionRegistry) Use(middleware ...ProjectionMiddleware) {
+	r.middleware = append(r.middleware, middleware...)
+}
+
+func (r *ProjectionRegistry) Register(source, eventType, version string, handler ProjectionHandler) error {
+	key := domain.EventTypeKey{Source: source, EventType: eventType, Version: version}
+	if _, ok := r.handlers[key]; ok {
+		return fmt.Errorf("%w: %s", ErrProjectionHandlerExists, key)
+	}
+	r.handlers[key] = handler
+	return nil
+}
+
+func (r *ProjectionRegistry) Lookup(source, eventType, version string) (ProjectionHandler, bool) {
+	handler, ok := r.handlers[domain.EventTypeKey{Source: source, EventType: eventType, Version: version}]
+	if !ok {
+		return nil, false
+	}
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		handler = r.middleware[i](handler)
+	}
+	return handler, true
+}
+
+func ProjectionMetrics(metrics *observability.Metrics) ProjectionMiddleware {
+	return func(next ProjectionHandler) ProjectionHandler {
+		return func(ctx context.Context, event *domain.Event) error {
+			if metrics == nil {
+				return next(ctx, event)
+			}
+
+			start := time.Now()
+			err := next(ctx, event)
+
+			dimensions := map[string]string{
+				"event_type":     event.EventType,
+				"correlation_id": event.CorrelationID,
+			}
+			metrics.RecordDuration(ctx, "projection_duration_ms", float64(time.Since(start).Milliseconds()), dimensions)
+			if err != nil {
+				metrics.IncrementCounter(ctx, "projection_errors", dimensions)
+			} else {
+				metrics.IncrementCounter(ctx, "projection_success", dimensions)
+			}
+			return err
+		}
+	}
+}
+
+// SkipProcessedEvents reports events the consumer has already processed as
+// domain.ErrEventAlreadyProcessed without calling the handler.
+func SkipProcessedEvents(processedRepo infra.ProcessedEventsRepository, logger *observability.Logger, metrics *observability.Metrics) ProjectionMiddleware {
+	return func(next ProjectionHandler) ProjectionHandler {
+		return func(ctx context.Context, event *domain.Event) error {
+			processed, err := processedRepo.IsProcessed(ctx, event.EventID)
+			if err != nil {
+				if metrics != nil {
+					metrics.IncrementCounter(ctx, "projection_check_errors", map[string]string{
+						"event_type":     event.EventType,
+						"correlation_id": event.CorrelationID,
+					})
+				}
+				logger.Error("failed to check if event is processed", err, map[string]interface{}{
+					"event_id": event.EventID,
+				})
+				return domain.NewRetriableError(err, "failed to check processed status")
+			}
+			if processed {
+				return domain.ErrEventAlreadyProcessed
+			}
+			return next(ctx, event)
+		}
+	}
+}
+
+// ProjectionIdempotency skips events the consumer has already processed and
+// acknowledges duplicates and stale events reported by the handler. Handlers
+// are expected to mark the event as processed in the same write as their
+// projection state.
+func ProjectionIdempotency(processedRepo infra.ProcessedEventsRepository, logger *observability.Logger, metrics *observability.Metrics) ProjectionMiddleware {
+	skipProcessed := SkipProcessedEvents(processedRepo, logger, metrics)
+	return func(next ProjectionHandler) ProjectionHandler {
+		handler := skipProcessed(next)
+		return func(ctx context.Context, event *domain.Event) error {
+			dimensions := map[string]string{
+				"event_type":     event.EventType,
+				"correlation_id": event.CorrelationID,
+			}
+
+			err := handler(ctx, event)
+			switch {
+			case errors.Is(err, domain.ErrEventAlreadyProcessed):
+				if metrics != nil {
+					metrics.IncrementCounter(ctx, "projection_idempotency_hits", dimensions)
+				}
+				return nil
+			case errors.Is(err, domain.ErrStaleEvent):
+				if metrics != nil {
+					metrics.IncrementCounter(ctx, "projection_stale_events", dimensions)
+				}
+				logger.Warn("ignoring event older than projection", map[string]interface{}{
+					"event_id":          event.EventID,
+					"event_type":        event.EventType,
+					"aggregate_version": event.AggregateVersion,
+				})
+				return nil
+			}
+			return err
+		}
+	}
+}
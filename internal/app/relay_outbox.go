@@ -0,0 +1,166 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+const (
+	defaultRelayBatchSize     = 25
+	defaultRelayMaxDeliveries = 10
+)
+
+type RelayOutboxUseCase struct {
+	outboxRepo    infra.OutboxRepository
+	publisher     infra.EventPublisher
+	logger        *observability.Logger
+	metrics       *observability.Metrics
+	batchSize     int
+	maxDeliveries int
+}
+
+func NewRelayOutboxUseCase(outboxRepo infra.OutboxRepository, publisher infra.EventPublisher, logger *observability.Logger, metrics *observability.Metrics) *RelayOutboxUseCase {
+	return &RelayOutboxUseCase{
+		outboxRepo:    outboxRepo,
+		publisher:     publisher,
+		logger:        logger,
+		metrics:       metrics,
+		batchSize:     defaultRelayBatchSize,
+		maxDeliveries: defaultRelayMaxDeliveries,
+	}
+}
+
+// Failed counts entries left in the outbox for a later run; DeadLettered
+// counts entries that were given up on and marked as failed.
+type RelayOutboxResult struct {
+	Published    int
+	Failed       int
+	DeadLettered int
+}
+
+func (uc *RelayOutboxUseCase) Execute(ctx context.Context) (RelayOutboxResult, error) {
+	start := time.Now()
+	defer func() {
+		if uc.metrics != nil {
+			duration := time.Since(start).Milliseconds()
+			uc.metrics.RecordDuration(ctx, "relay_outbox_duration_ms", float64(duration), nil)
+		}
+	}()
+
+	// Each run walks the outbox once, so entries that fail are not fetched
+	// again until the next run and cannot hold back the ones behind them.
+	var result RelayOutboxResult
+	cursor := ""
+	for {
+		entries, next, err := uc.outboxRepo.GetPendingEvents(ctx, cursor, uc.batchSize)
+		if err != nil {
+			if uc.metrics != nil {
+				uc.metrics.IncrementCounter(ctx, "relay_outbox_read_errors", nil)
+			}
+			uc.logger.Error("failed to read outbox", err)
+			return result, err
+		}
+
+		if len(entries) > 0 {
+			events := make([]*domain.Event, len(entries))
+			for i, entry := range entries {
+				events[i] = entry.Event
+			}
+			// The publisher already retries retriable entries, so an entry
+			// that still fails counts as one delivery attempt and is left
+			// for the next run.
+			results := uc.publisher.PublishEvents(ctx, events)
+
+			for i, entry := range entries {
+				uc.settle(ctx, entry, results[i].Err, &result)
+			}
+		}
+
+		if next == "" {
+			return result, nil
+		}
+		cursor = next
+	}
+}
+
+func (uc *RelayOutboxUseCase) settle(ctx context.Context, entry *infra.OutboxEntry, publishErr error, result *RelayOutboxResult) {
+	event := entry.Event
+
+	if err := publishErr; err != nil {
+		if isPermanentPublishError(err) || entry.Attempts+1 >= uc.maxDeliveries {
+			uc.deadLetter(ctx, entry, err, result)
+			return
+		}
+
+		if uc.metrics != nil {
+			uc.metrics.IncrementCounter(ctx, "relay_outbox_publish_errors", map[string]string{
+				"correlation_id": event.CorrelationID,
+			})
+		}
+		uc.logger.Error("failed to publish outbox event", err, map[string]interface{}{
+			"event_id": event.EventID,
+			"order_id": event.OrderID,
+			"attempts": entry.Attempts + 1,
+		})
+		if recordErr := uc.outboxRepo.RecordFailure(ctx, event.EventID, err); recordErr != nil {
+			uc.logger.Error("failed to record outbox failure", recordErr, map[string]interface{}{
+				"event_id": event.EventID,
+			})
+		}
+		result.Failed++
+		return
+	}
+
+	if err := uc.outboxRepo.MarkAsPublished(ctx, event.EventID); err != nil {
+		if uc.metrics != nil {
+			uc.metrics.IncrementCounter(ctx, "relay_outbox_mark_published_errors", map[string]string{
+				"correlation_id": event.CorrelationID,
+			})
+		}
+		uc.logger.Error("failed to mark outbox event as published", err, map[string]interface{}{
+			"event_id": event.EventID,
+		})
+		result.Failed++
+		return
+	}
+
+	if uc.metrics != nil {
+		uc.metrics.IncrementCounter(ctx, "relay_outbox_published", map[string]string{
+			"correlation_id": event.CorrelationID,
+		})
+	}
+	result.Published++
+}
+
+func (uc *RelayOutboxUseCase) deadLetter(ctx context.Context, entry *infra.OutboxEntry, cause error, result *RelayOutboxResult) {
+	event := entry.Event
+
+	if uc.metrics != nil {
+		uc.metrics.IncrementCounter(ctx, "relay_outbox_dead_lettered", map[string]string{
+			"correlation_id": event.CorrelationID,
+		})
+	}
+	uc.logger.Error("giving up on outbox event", cause, map[string]interface{}{
+		"event_id": event.EventID,
+		"order_id": event.OrderID,
+		"attempts": entry.Attempts + 1,
+	})
+	if err := uc.outboxRepo.MarkAsFailed(ctx, event.EventID, cause); err != nil {
+		uc.logger.Error("failed to mark outbox event as failed", err, map[string]interface{}{
+			"event_id": event.EventID,
+		})
+		result.Failed++
+		return
+	}
+	result.DeadLettered++
+}
+
+func isPermanentPublishError(err error) bool {
+	var appErr *domain.AppError
+	return errors.As(err, &appErr) && !appErr.Retriable
+}
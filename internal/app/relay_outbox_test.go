@@ -0,0 +1,122 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/testkit"
+)
+
+func TestRelayOutboxUseCase(t *testing.T) {
+	ctx := context.Background()
+	throttled := domain.NewRetriableError(errors.New("ThrottlingException"), "put events entry failed")
+	rejected := domain.NewNonRetriableError(errors.New("MalformedDetail"), "put events entry rejected")
+
+	outbox := testkit.NewEventRepository()
+	for i := 1; i <= 4; i++ {
+		event := &domain.Event{EventID: fmt.Sprintf("event-%d", i), OrderID: domain.OrderID(fmt.Sprintf("order-%d", i)), AggregateVersion: 1}
+		if err := outbox.Seed(ctx, event); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	publisher := testkit.NewEventPublisher()
+	publisher.InjectEventFailure("event-2", throttled, 1)
+	publisher.InjectEventFailure("event-3", rejected, 0)
+
+	uc := NewRelayOutboxUseCase(outbox, publisher, observability.NewLogger("", ""), nil)
+
+	result, err := uc.Execute(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Published != 2 || result.Failed != 1 || result.DeadLettered != 1 {
+		t.Errorf("expected 2 published, 1 failed and 1 dead-lettered, got %+v", result)
+	}
+	publisher.AssertCallCount(t, testkit.MethodPublishEvents, 1)
+	outbox.AssertCallCount(t, testkit.MethodRecordFailure, 1)
+	outbox.AssertCallCount(t, testkit.MethodMarkAsFailed, 1)
+
+	// The throttled entry is not resent within the run; the next run picks it up.
+	if result, err = uc.Execute(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Published != 1 || result.Failed != 0 {
+		t.Errorf("expected the throttled event to be published on the next run, got %+v", result)
+	}
+	publisher.AssertCallCount(t, testkit.MethodPublishEvents, 2)
+	publisher.AssertPublishedCount(t, 3)
+
+	if pending := outbox.PendingOutbox(ctx); len(pending) != 0 {
+		t.Errorf("expected the outbox to be drained, got %d pending", len(pending))
+	}
+	failed := outbox.FailedOutbox(ctx)
+	if len(failed) != 1 || failed[0].Event.EventID != "event-3" || failed[0].Attempts != 1 {
+		t.Errorf("expected the rejected event to be marked as failed, got %d failed", len(failed))
+	}
+}
+
+func TestRelayOutboxUseCasePermanentlyFailingFirstPage(t *testing.T) {
+	ctx := context.Background()
+	throttled := domain.NewRetriableError(errors.New("ThrottlingException"), "put events entry failed")
+
+	outbox := testkit.NewEventRepository()
+	for i := 1; i <= 5; i++ {
+		event := &domain.Event{EventID: fmt.Sprintf("event-%d", i), OrderID: domain.OrderID(fmt.Sprintf("order-%d", i)), AggregateVersion: 1}
+		if err := outbox.Seed(ctx, event); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	publisher := testkit.NewEventPublisher()
+	publisher.InjectEventFailure("event-1", throttled, 0)
+	publisher.InjectEventFailure("event-2", throttled, 0)
+
+	uc := NewRelayOutboxUseCase(outbox, publisher, observability.NewLogger("", ""), nil)
+	uc.batchSize = 2
+	uc.maxDeliveries = 3
+
+	result, err := uc.Execute(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Published != 3 || result.Failed != 2 {
+		t.Errorf("expected the entries behind the failing page to be published, got %+v", result)
+	}
+	outbox.AssertCallCount(t, testkit.MethodGetPendingEvents, 3)
+
+	for run := 2; run <= 3; run++ {
+		if result, err = uc.Execute(ctx); err != nil {
+			t.Fatalf("run %d: unexpected error: %v", run, err)
+		}
+	}
+	if result.Failed != 0 || result.DeadLettered != 2 {
+		t.Errorf("expected both entries to be given up on after 3 deliveries, got %+v", result)
+	}
+	if pending := outbox.PendingOutbox(ctx); len(pending) != 0 {
+		t.Errorf("expected no pending entries, got %d", len(pending))
+	}
+	if failed := outbox.FailedOutbox(ctx); len(failed) != 2 || failed[0].Attempts != 3 {
+		t.Errorf("expected 2 failed entries after 3 attempts, got %+v", failed)
+	}
+
+	if result, _ := uc.Execute(ctx); result.Published+result.Failed+result.DeadLettered != 0 {
+		t.Errorf("failed entries must not be fetched again, got %+v", result)
+	}
+}
+
+func TestRelayOutboxUseCaseReadFailure(t *testing.T) {
+	outbox := testkit.NewEventRepository()
+	outbox.InjectFailure(testkit.MethodGetPendingEvents, errors.New("table unavailable"), 1)
+	publisher := testkit.NewEventPublisher()
+
+	uc := NewRelayOutboxUseCase(outbox, publisher, observability.NewLogger("", ""), nil)
+	if _, err := uc.Execute(context.Background()); err == nil {
+		t.Fatal("expected the read failure to be returned")
+	}
+	publisher.AssertNothingPublished(t)
+}
@@ -0,0 +1,231 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+const defaultReplayPageSize = 100
+
+type ReplayFilter struct {
+	OrderID    domain.OrderID
+	EventTypes []string
+	From       time.Time
+	To         time.Time
+}
+
+func (f ReplayFilter) Matches(event *domain.Event) bool {
+	if f.OrderID != "" && event.OrderID != f.OrderID {
+		return false
+	}
+	if len(f.EventTypes) > 0 {
+		matched := false
+		for _, eventType := range f.EventTypes {
+			if event.EventType == eventType {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if !f.From.IsZero() && event.CreatedAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !event.CreatedAt.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+// RunID must match the run ID of a stored checkpoint; the caller scopes the
+// processed-event markers of the projection by it.
+type ReplayOptions struct {
+	RunID         string
+	Filter        ReplayFilter
+	DryRun        bool
+	RatePerSecond int
+	PageSize      int
+}
+
+// Duplicates were already projected by this run, Stale events were older than
+// the target row and left it unchanged. Orphaned events change an order the
+// target does not hold, typically because the filter excluded its creation.
+type ReplayResult struct {
+	Scanned    int
+	Matched    int
+	Projected  int
+	Duplicates int
+	Stale      int
+	Orphaned   int
+	Rejected   int
+}
+
+// ReplayEventsUseCase feeds stored events through the projection handlers. It
+// only writes what the projections write; nothing is published or appended
+// to the outbox.
+type ReplayEventsUseCase struct {
+	eventRepo    infra.EventRepository
+	scanner      infra.EventStoreScanner
+	projectEvent *ProjectEventUseCase
+	checkpoints  infra.CheckpointStore
+	logger       *observability.Logger
+	metrics      *observability.Metrics
+}
+
+func NewReplayEventsUseCase(
+	eventRepo infra.EventRepository,
+	scanner infra.EventStoreScanner,
+	projectEvent *ProjectEventUseCase,
+	checkpoints infra.CheckpointStore,
+	logger *observability.Logger,
+	metrics *observability.Metrics,
+) *ReplayEventsUseCase {
+	return &ReplayEventsUseCase{
+		eventRepo:    eventRepo,
+		scanner:      scanner,
+		projectEvent: projectEvent,
+		checkpoints:  checkpoints,
+		logger:       logger,
+		metrics:      metrics,
+	}
+}
+
+// Execute replays a single order stream directly, without a checkpoint, and
+// everything else with a full scan. The scan resumes from the stored checkpoint and advances it after
+// every completed page, so an aborted replay can be restarted with the same
+// run ID; the events of the page that was in flight come back as duplicates.
+func (uc *ReplayEventsUseCase) Execute(ctx context.Context, opts ReplayOptions) (*ReplayResult, error) {
+	result := &ReplayResult{}
+
+	var throttle <-chan time.Time
+	if opts.RatePerSecond > 0 && !opts.DryRun {
+		ticker := time.NewTicker(time.Second / time.Duration(opts.RatePerSecond))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	if opts.Filter.OrderID != "" {
+		events, err := uc.eventRepo.GetEventsByOrderID(ctx, opts.Filter.OrderID)
+		if err != nil {
+			uc.logger.Error("failed to load events", err, map[string]interface{}{
+				"order_id": opts.Filter.OrderID,
+			})
+			return result, domain.NewRetriableError(err, "failed to load events")
+		}
+		return result, uc.replayPage(ctx, events, opts, throttle, result)
+	}
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultReplayPageSize
+	}
+
+	cursor := ""
+	if uc.checkpoints != nil {
+		checkpoint, err := uc.checkpoints.LoadCheckpoint(ctx)
+		if err != nil {
+			uc.logger.Error("failed to load checkpoint", err)
+			return result, domain.NewRetriableError(err, "failed to load checkpoint")
+		}
+		if checkpoint != nil {
+			if checkpoint.RunID != opts.RunID {
+				return result, domain.NewNonRetriableError(
+					fmt.Errorf("checkpoint belongs to replay run %s", checkpoint.RunID),
+					"replay run does not match checkpoint",
+				)
+			}
+			cursor = checkpoint.Cursor
+		}
+	}
+
+	for {
+		events, next, err := uc.scanner.ScanEvents(ctx, cursor, pageSize)
+		if err != nil {
+			uc.logger.Error("failed to scan events", err, map[string]interface{}{
+				"cursor": cursor,
+			})
+			return result, domain.NewRetriableError(err, "failed to scan events")
+		}
+
+		if err := uc.replayPage(ctx, events, opts, throttle, result); err != nil {
+			return result, err
+		}
+
+		if uc.checkpoints != nil && !opts.DryRun {
+			if err := uc.checkpoints.SaveCheckpoint(ctx, &infra.ReplayCheckpoint{RunID: opts.RunID, Cursor: next}); err != nil {
+				uc.logger.Error("failed to save checkpoint", err, map[string]interface{}{
+					"cursor": next,
+				})
+				return result, domain.NewRetriableError(err, "failed to save checkpoint")
+			}
+		}
+
+		if next == "" {
+			return result, nil
+		}
+		cursor = next
+	}
+}
+
+// Events a projection rejects as invalid, and lifecycle events whose order is
+// not in the target, are counted and skipped so a filtered replay or one bad
+// historical event does not block a rebuild; any other failure aborts.
+func (uc *ReplayEventsUseCase) replayPage(ctx context.Context, events []*domain.Event, opts ReplayOptions, throttle <-chan time.Time, result *ReplayResult) error {
+	for _, event := range events {
+		result.Scanned++
+		if !opts.Filter.Matches(event) {
+			continue
+		}
+		result.Matched++
+		if opts.DryRun {
+			continue
+		}
+
+		if throttle != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-throttle:
+			}
+		}
+
+		err := uc.projectEvent.Execute(ctx, event)
+		var appErr *domain.AppError
+		switch {
+		case err == nil:
+			result.Projected++
+		case errors.Is(err, domain.ErrEventAlreadyProcessed):
+			result.Duplicates++
+		case errors.Is(err, domain.ErrStaleEvent):
+			result.Stale++
+		case errors.Is(err, domain.ErrOrderNotFound):
+			result.Orphaned++
+			uc.logger.Warn("skipping event for an order missing from the target", map[string]interface{}{
+				"event_id": event.EventID,
+				"order_id": event.OrderID,
+			})
+		case errors.As(err, &appErr) && !appErr.Retriable:
+			result.Rejected++
+			if uc.metrics != nil {
+				uc.metrics.IncrementCounter(ctx, "replay_rejected_events", map[string]string{
+					"event_type": event.EventType,
+				})
+			}
+			uc.logger.Error("event rejected during replay", err, map[string]interface{}{
+				"event_id": event.EventID,
+				"order_id": event.OrderID,
+			})
+		default:
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/testkit"
+)
+
+// failingScanner fails once the wrapped scanner has served the given number of
+// pages.
+type failingScanner struct {
+	infra.EventStoreScanner
+	pages int
+}
+
+func (s *failingScanner) ScanEvents(ctx context.Context, cursor string, limit int) ([]*domain.Event, string, error) {
+	if s.pages == 0 {
+		return nil, "", errors.New("throughput exceeded")
+	}
+	s.pages--
+	return s.EventStoreScanner.ScanEvents(ctx, cursor, limit)
+}
+
+func seedEventStore(t *testing.T) *infra.InMemoryEventRepository {
+	t.Helper()
+	ctx := context.Background()
+	repo := infra.NewInMemoryEventRepository()
+	for _, id := range []string{"order-1", "order-2", "order-3"} {
+		order, err := domain.NewOrder(domain.OrderID(id), "customer-1", []domain.LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: domain.Money{Amount: 1500, Currency: "EUR"}}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.SaveEvent(ctx, domain.NewOrderCreatedEvent(id+"-created", "corr-1", order), 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		confirmed, err := order.Confirm(id+"-confirmed", "corr-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.SaveEvent(ctx, confirmed, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	return repo
+}
+
+func newOrderReplay(t *testing.T, repo infra.ProjectionRepository) *ProjectEventUseCase {
+	t.Helper()
+	logger := observability.NewLogger("", "")
+	registry, err := NewOrderReplayRegistry(repo, logger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return NewProjectEventUseCase(registry, logger, nil)
+}
+
+func TestReplayEventsUseCase(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		opts          ReplayOptions
+		wantResult    ReplayResult
+		wantConfirmed []domain.OrderID
+		wantCreated   []domain.OrderID
+		wantMissing   []domain.OrderID
+	}{
+		{
+			name:          "replays everything",
+			opts:          ReplayOptions{PageSize: 4},
+			wantResult:    ReplayResult{Scanned: 6, Matched: 6, Projected: 6},
+			wantConfirmed: []domain.OrderID{"order-1", "order-2", "order-3"},
+		},
+		{
+			name:          "single order",
+			opts:          ReplayOptions{Filter: ReplayFilter{OrderID: "order-2"}},
+			wantResult:    ReplayResult{Scanned: 2, Matched: 2, Projected: 2},
+			wantConfirmed: []domain.OrderID{"order-2"},
+			wantMissing:   []domain.OrderID{"order-1", "order-3"},
+		},
+		{
+			name:        "event type filter",
+			opts:        ReplayOptions{Filter: ReplayFilter{EventTypes: []string{domain.EventTypeOrderCreated}}},
+			wantResult:  ReplayResult{Scanned: 6, Matched: 3, Projected: 3},
+			wantCreated: []domain.OrderID{"order-1", "order-2", "order-3"},
+		},
+		{
+			name:        "time range excludes everything",
+			opts:        ReplayOptions{Filter: ReplayFilter{To: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}},
+			wantResult:  ReplayResult{Scanned: 6},
+			wantMissing: []domain.OrderID{"order-1", "order-2", "order-3"},
+		},
+		{
+			name:        "dry run writes nothing",
+			opts:        ReplayOptions{DryRun: true, PageSize: 4},
+			wantResult:  ReplayResult{Scanned: 6, Matched: 6},
+			wantMissing: []domain.OrderID{"order-1", "order-2", "order-3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRepo := seedEventStore(t)
+			projectionRepo := testkit.NewProjectionRepository()
+			checkpoints := infra.NewFileCheckpointStore(filepath.Join(t.TempDir(), "replay.checkpoint"))
+			uc := NewReplayEventsUseCase(eventRepo, eventRepo, newOrderReplay(t, projectionRepo), checkpoints, observability.NewLogger("", ""), nil)
+
+			result, err := uc.Execute(ctx, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *result != tt.wantResult {
+				t.Errorf("expected %+v, got %+v", tt.wantResult, *result)
+			}
+			for _, id := range tt.wantConfirmed {
+				projectionRepo.AssertOrderStatus(t, id, domain.OrderStatusConfirmed)
+			}
+			for _, id := range tt.wantCreated {
+				projectionRepo.AssertOrderStatus(t, id, domain.OrderStatusCreated)
+			}
+			for _, id := range tt.wantMissing {
+				if order, _ := projectionRepo.GetOrder(ctx, id); order != nil {
+					t.Errorf("expected %s not to be projected", id)
+				}
+			}
+			if checkpoint, _ := checkpoints.LoadCheckpoint(ctx); checkpoint != nil {
+				t.Errorf("expected no checkpoint after a completed replay, got %+v", checkpoint)
+			}
+		})
+	}
+
+	t.Run("resumes from the checkpoint", func(t *testing.T) {
+		eventRepo := seedEventStore(t)
+		projectionRepo := testkit.NewProjectionRepository()
+		projectEvent := newOrderReplay(t, projectionRepo)
+		checkpoints := infra.NewFileCheckpointStore(filepath.Join(t.TempDir(), "replay.checkpoint"))
+		logger := observability.NewLogger("", "")
+		opts := ReplayOptions{RunID: "run-1", PageSize: 2}
+
+		aborted := NewReplayEventsUseCase(eventRepo, &failingScanner{EventStoreScanner: eventRepo, pages: 2}, projectEvent, checkpoints, logger, nil)
+		result, err := aborted.Execute(ctx, opts)
+		if !domain.IsRetriable(err) {
+			t.Fatalf("expected a retriable error, got %v", err)
+		}
+		if result.Projected != 4 {
+			t.Fatalf("expected 4 events projected before the failure, got %d", result.Projected)
+		}
+		if checkpoint, _ := checkpoints.LoadCheckpoint(ctx); checkpoint == nil || checkpoint.RunID != "run-1" {
+			t.Fatalf("expected a checkpoint for run-1 after the aborted replay, got %+v", checkpoint)
+		}
+
+		other := NewReplayEventsUseCase(eventRepo, eventRepo, projectEvent, checkpoints, logger, nil)
+		if _, err := other.Execute(ctx, ReplayOptions{RunID: "run-2", PageSize: 2}); err == nil || domain.IsRetriable(err) {
+			t.Fatalf("expected a new run to refuse the checkpoint of run-1, got %v", err)
+		}
+
+		result, err = NewReplayEventsUseCase(eventRepo, eventRepo, projectEvent, checkpoints, logger, nil).Execute(ctx, opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Scanned != 2 || result.Projected != 2 {
+			t.Errorf("expected only the remaining page to be replayed, got %+v", *result)
+		}
+		projectionRepo.AssertOrderStatus(t, "order-3", domain.OrderStatusConfirmed)
+		projectionRepo.AssertCallCount(t, testkit.MethodSaveOrderAndMarkProcessed, 6)
+	})
+	t.Run("reports duplicates and stale events", func(t *testing.T) {
+		eventRepo := seedEventStore(t)
+		projectionRepo := testkit.NewProjectionRepository()
+		if err := projectionRepo.SaveOrder(ctx, &domain.Order{ID: "order-1", Status: domain.OrderStatusDelivered, Version: 4}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		uc := NewReplayEventsUseCase(eventRepo, eventRepo, newOrderReplay(t, projectionRepo), nil, observability.NewLogger("", ""), nil)
+
+		result, err := uc.Execute(ctx, ReplayOptions{RunID: "run-1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := (ReplayResult{Scanned: 6, Matched: 6, Projected: 4, Stale: 2}); *result != want {
+			t.Errorf("expected %+v, got %+v", want, *result)
+		}
+		projectionRepo.AssertOrderStatus(t, "order-1", domain.OrderStatusDelivered)
+
+		result, err = uc.Execute(ctx, ReplayOptions{RunID: "run-1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := (ReplayResult{Scanned: 6, Matched: 6, Duplicates: 4, Stale: 2}); *result != want {
+			t.Errorf("expected %+v, got %+v", want, *result)
+		}
+	})
+	t.Run("time window into an empty target skips orphaned events", func(t *testing.T) {
+		createdAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+		eventRepo := infra.NewInMemoryEventRepository()
+		order, err := domain.NewOrder("order-1", "customer-1", []domain.LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: domain.Money{Amount: 1500, Currency: "EUR"}}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		created := domain.NewOrderCreatedEvent("order-1-created", "corr-1", order)
+		created.CreatedAt = createdAt
+		confirmed, err := order.Confirm("order-1-confirmed", "corr-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		confirmed.CreatedAt = createdAt.Add(time.Hour)
+		for i, event := range []*domain.Event{created, confirmed} {
+			if err := eventRepo.SaveEvent(ctx, event, int64(i)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		projectionRepo := testkit.NewProjectionRepository()
+		checkpoints := infra.NewFileCheckpointStore(filepath.Join(t.TempDir(), "replay.checkpoint"))
+		uc := NewReplayEventsUseCase(eventRepo, eventRepo, newOrderReplay(t, projectionRepo), checkpoints, observability.NewLogger("", ""), nil)
+
+		result, err := uc.Execute(ctx, ReplayOptions{RunID: "run-1", Filter: ReplayFilter{From: createdAt.Add(30 * time.Minute)}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := (ReplayResult{Scanned: 2, Matched: 1, Orphaned: 1}); *result != want {
+			t.Errorf("expected %+v, got %+v", want, *result)
+		}
+		if order, _ := projectionRepo.GetOrder(ctx, "order-1"); order != nil {
+			t.Errorf("expected order-1 not to be projected, got %+v", order)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+type ShipOrderUseCase struct {
+	orderLifecycleUseCase
+}
+
+func NewShipOrderUseCase(eventRepo infra.EventRepository, orderRepo infra.OrderRepository, logger *observability.Logger, metrics *observability.Metrics) *ShipOrderUseCase {
+	return &ShipOrderUseCase{
+		orderLifecycleUseCase: orderLifecycleUseCase{
+			eventRepo: eventRepo,
+			orderRepo: orderRepo,
+			logger:    logger,
+			metrics:   metrics,
+		},
+	}
+}
+
+type ShipOrderRequest struct {
+	OrderID        string
+	TrackingNumber string
+}
+
+func (uc *ShipOrderUseCase) Execute(ctx context.Context, req ShipOrderRequest, correlationID string) (*domain.Order, error) {
+	return uc.execute(ctx, "ship_order", domain.OrderID(req.OrderID), correlationID, func(order *domain.Order, eventID string) (*domain.Event, error) {
+		return order.Ship(eventID, correlationID, req.TrackingNumber)
+	})
+}
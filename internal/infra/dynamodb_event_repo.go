@@ -4,51 +4,60 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
-	"github.com/stevenbode/go-serverless-event-platform/internal/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
 	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
 )
 
 type DynamoDBEventRepository struct {
-	client    *dynamodb.Client
-	tableName string
-	logger    *observability.Logger
+	client          DynamoDBEventStoreAPI
+	tableName       string
+	outboxTableName string
+	logger          *observability.Logger
 }
 
-func NewDynamoDBEventRepository(client *dynamodb.Client, tableName string, logger *observability.Logger) *DynamoDBEventRepository {
+func NewDynamoDBEventRepository(client DynamoDBEventStoreAPI, tableName, outboxTableName string, logger *observability.Logger) *DynamoDBEventRepository {
 	return &DynamoDBEventRepository{
-		client:    client,
-		tableName: tableName,
-		logger:    logger,
+		client:          client,
+		tableName:       tableName,
+		outboxTableName: outboxTableName,
+		logger:          logger,
 	}
 }
 
 type EventItem struct {
-	EventID       string `dynamodbav:"event_id"`
-	OrderID       string `dynamodbav:"order_id"`
-	EventType     string `dynamodbav:"event_type"`
-	Source        string `dynamodbav:"source"`
-	Version       string `dynamodbav:"version"`
-	CorrelationID string `dynamodbav:"correlation_id"`
-	CreatedAt     string `dynamodbav:"created_at"`
-	Data          string `dynamodbav:"data"`
+	OrderID          string `dynamodbav:"order_id"`
+	AggregateVersion int64  `dynamodbav:"aggregate_version"`
+	EventID          string `dynamodbav:"event_id"`
+	EventType        string `dynamodbav:"event_type"`
+	Source           string `dynamodbav:"source"`
+	Version          string `dynamodbav:"version"`
+	CorrelationID    string `dynamodbav:"correlation_id"`
+	CreatedAt        string `dynamodbav:"created_at"`
+	Data             string `dynamodbav:"data"`
 }
 
-func (r *DynamoDBEventRepository) SaveEvent(ctx context.Context, event *domain.Event) error {
+func (r *DynamoDBEventRepository) SaveEvent(ctx context.Context, event *domain.Event, expectedVersion int64) error {
+	if event.AggregateVersion != expectedVersion+1 {
+		return fmt.Errorf("event version %d does not follow expected version %d", event.AggregateVersion, expectedVersion)
+	}
+
 	item := EventItem{
-		EventID:       event.EventID,
-		OrderID:       string(event.OrderID),
-		EventType:     event.EventType,
-		Source:        event.Source,
-		Version:       event.Version,
-		CorrelationID: event.CorrelationID,
-		CreatedAt:     event.CreatedAt.Format("2006-01-02T15:04:05.000Z"),
-		Data:          string(event.Data),
+		OrderID:          string(event.OrderID),
+		AggregateVersion: event.AggregateVersion,
+		EventID:          event.EventID,
+		EventType:        event.EventType,
+		Source:           event.Source,
+		Version:          event.Version,
+		CorrelationID:    event.CorrelationID,
+		CreatedAt:        event.CreatedAt.Format("2006-01-02T15:04:05.000Z"),
+		Data:             string(event.Data),
 	}
 
 	av, err := attributevalue.MarshalMap(item)
@@ -57,20 +66,60 @@ func (r *DynamoDBEventRepository) SaveEvent(ctx context.Context, event *domain.E
 		return fmt.Errorf("marshal event: %w", err)
 	}
 
-	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName:           aws.String(r.tableName),
-		Item:                av,
-		ConditionExpression: aws.String("attribute_not_exists(event_id)"),
+	outboxAV, err := newOutboxItem(event)
+	if err != nil {
+		r.logger.Error("failed to marshal outbox entry", err)
+		return err
+	}
+
+	_, err = r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Put: &types.Put{
+					TableName:           aws.String(r.tableName),
+					Item:                av,
+					ConditionExpression: aws.String("attribute_not_exists(order_id)"),
+				},
+			},
+			{
+				Put: &types.Put{
+					TableName:           aws.String(r.outboxTableName),
+					Item:                outboxAV,
+					ConditionExpression: aws.String("attribute_not_exists(event_id)"),
+				},
+			},
+		},
 	})
 
 	if err != nil {
-		var condCheckErr *types.ConditionalCheckFailedException
-		if errors.As(err, &condCheckErr) {
-			r.logger.Warn("event already exists", map[string]interface{}{
+		// Every order stream starts at aggregate_version 1, so the first
+		// event's put doubles as the uniqueness guard for client supplied
+		// order ids.
+		if conditionalCheckFailedAt(err, 0) && expectedVersion == 0 {
+			r.logger.Warn("order already exists", map[string]interface{}{
 				"event_id": event.EventID,
+				"order_id": event.OrderID,
 			})
 			return domain.ErrOrderAlreadyExists
 		}
+		if conditionalCheckFailedAt(err, 0) {
+			r.logger.Warn("concurrent write to order stream", map[string]interface{}{
+				"event_id":         event.EventID,
+				"order_id":         event.OrderID,
+				"expected_version": expectedVersion,
+			})
+			return &domain.ConcurrencyError{
+				OrderID:         event.OrderID,
+				ExpectedVersion: expectedVersion,
+			}
+		}
+		if conditionalCheckFailedAt(err, 1) {
+			r.logger.Error("outbox entry already exists", err, map[string]interface{}{
+				"event_id": event.EventID,
+				"order_id": event.OrderID,
+			})
+			return fmt.Errorf("save event: duplicate outbox entry for event %s: %w", event.EventID, err)
+		}
 		r.logger.Error("failed to save event", err, map[string]interface{}{
 			"event_id": event.EventID,
 		})
@@ -78,52 +127,102 @@ func (r *DynamoDBEventRepository) SaveEvent(ctx context.Context, event *domain.E
 	}
 
 	r.logger.Info("event saved", map[string]interface{}{
-		"event_id": event.EventID,
-		"order_id": event.OrderID,
+		"event_id":          event.EventID,
+		"order_id":          event.OrderID,
+		"aggregate_version": event.AggregateVersion,
 	})
 
 	return nil
 }
 
 func (r *DynamoDBEventRepository) GetEventsByOrderID(ctx context.Context, orderID domain.OrderID) ([]*domain.Event, error) {
-	result, err := r.client.Query(ctx, &dynamodb.QueryInput{
-		TableName:              aws.String(r.tableName),
-		IndexName:              aws.String("order_id-index"),
-		KeyConditionExpression: aws.String("order_id = :order_id"),
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":order_id": &types.AttributeValueMemberS{Value: string(orderID)},
-		},
-	})
+	return r.GetEventsAfterVersion(ctx, orderID, 0)
+}
 
-	if err != nil {
-		r.logger.Error("failed to query events", err, map[string]interface{}{
-			"order_id": orderID,
+func (r *DynamoDBEventRepository) GetEventsAfterVersion(ctx context.Context, orderID domain.OrderID, afterVersion int64) ([]*domain.Event, error) {
+	var events []*domain.Event
+	var startKey map[string]types.AttributeValue
+
+	for {
+		result, err := r.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(r.tableName),
+			KeyConditionExpression: aws.String("order_id = :order_id AND aggregate_version > :after_version"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":order_id":      &types.AttributeValueMemberS{Value: string(orderID)},
+				":after_version": &types.AttributeValueMemberN{Value: strconv.FormatInt(afterVersion, 10)},
+			},
+			ScanIndexForward:  aws.Bool(true),
+			ConsistentRead:    aws.Bool(true),
+			ExclusiveStartKey: startKey,
 		})
-		return nil, fmt.Errorf("query events: %w", err)
-	}
 
-	var events []*domain.Event
-	for _, item := range result.Items {
-		var eventItem EventItem
-		if err := attributevalue.UnmarshalMap(item, &eventItem); err != nil {
-			r.logger.Error("failed to unmarshal event", err)
-			continue
+		if err != nil {
+			r.logger.Error("failed to query events", err, map[string]interface{}{
+				"order_id": orderID,
+			})
+			return nil, fmt.Errorf("query events: %w", err)
+		}
+
+		for _, item := range result.Items {
+			var eventItem EventItem
+			if err := attributevalue.UnmarshalMap(item, &eventItem); err != nil {
+				r.logger.Error("failed to unmarshal event", err)
+				return nil, fmt.Errorf("unmarshal event: %w", err)
+			}
+			event, err := domain.UpcastEvent(eventItem.toDomain())
+			if err != nil {
+				r.logger.Error("failed to upcast event", err, map[string]interface{}{
+					"event_id": eventItem.EventID,
+					"version":  eventItem.Version,
+				})
+				return nil, fmt.Errorf("upcast event: %w", err)
+			}
+			events = append(events, event)
 		}
 
-		event := &domain.Event{
-			EventID:       eventItem.EventID,
-			CorrelationID: eventItem.CorrelationID,
-			EventType:     eventItem.EventType,
-			Source:        eventItem.Source,
-			Version:       eventItem.Version,
-			OrderID:       domain.OrderID(eventItem.OrderID),
-			CreatedAt:     parseTime(eventItem.CreatedAt),
-			Data:          []byte(eventItem.Data),
+		if len(result.LastEvaluatedKey) == 0 {
+			return events, nil
 		}
-		events = append(events, event)
+		startKey = result.LastEvaluatedKey
 	}
+}
 
-	return events, nil
+func (item EventItem) toDomain() *domain.Event {
+	return &domain.Event{
+		EventID:          item.EventID,
+		CorrelationID:    item.CorrelationID,
+		EventType:        item.EventType,
+		Source:           item.Source,
+		Version:          item.Version,
+		AggregateVersion: item.AggregateVersion,
+		OrderID:          domain.OrderID(item.OrderID),
+		CreatedAt:        parseTime(item.CreatedAt),
+		Data:             []byte(item.Data),
+	}
+}
+
+func isConditionalCheckFailure(err error) bool {
+	var condCheckErr *types.ConditionalCheckFailedException
+	if errors.As(err, &condCheckErr) {
+		return true
+	}
+	var txCanceledErr *types.TransactionCanceledException
+	if errors.As(err, &txCanceledErr) {
+		for _, reason := range txCanceledErr.CancellationReasons {
+			if aws.ToString(reason.Code) == "ConditionalCheckFailed" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func conditionalCheckFailedAt(err error, index int) bool {
+	var txCanceledErr *types.TransactionCanceledException
+	if !errors.As(err, &txCanceledErr) || index >= len(txCanceledErr.CancellationReasons) {
+		return false
+	}
+	return aws.ToString(txCanceledErr.CancellationReasons[index].Code) == "ConditionalCheckFailed"
 }
 
 func parseTime(s string) time.Time {
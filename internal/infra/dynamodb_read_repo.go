@@ -3,23 +3,24 @@ package infra
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
-	"github.com/stevenbode/go-serverless-event-platform/internal/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
 	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
 )
 
 type DynamoDBReadModelRepository struct {
-	client    *dynamodb.Client
+	client    DynamoDBItemAPI
 	tableName string
 	logger    *observability.Logger
 }
 
-func NewDynamoDBReadModelRepository(client *dynamodb.Client, tableName string, logger *observability.Logger) *DynamoDBReadModelRepository {
+func NewDynamoDBReadModelRepository(client DynamoDBItemAPI, tableName string, logger *observability.Logger) *DynamoDBReadModelRepository {
 	return &DynamoDBReadModelRepository{
 		client:    client,
 		tableName: tableName,
@@ -28,32 +29,46 @@ func NewDynamoDBReadModelRepository(client *dynamodb.Client, tableName string, l
 }
 
 type OrderItem struct {
-	OrderID    string `dynamodbav:"order_id"`
-	CustomerID string `dynamodbav:"customer_id"`
-	TotalCents int64  `dynamodbav:"total_cents"`
-	CreatedAt  string `dynamodbav:"created_at"`
+	OrderID    string          `dynamodbav:"order_id"`
+	CustomerID string          `dynamodbav:"customer_id"`
+	Items      []OrderLineItem `dynamodbav:"items"`
+	Currency   string          `dynamodbav:"currency"`
+	TotalCents int64           `dynamodbav:"total_cents"`
+	Status     string          `dynamodbav:"status"`
+	Version    int64           `dynamodbav:"version"`
+	CreatedAt  string          `dynamodbav:"created_at"`
+	UpdatedAt  string          `dynamodbav:"updated_at,omitempty"`
 }
 
-func (r *DynamoDBReadModelRepository) SaveOrder(ctx context.Context, order *domain.Order) error {
-	item := OrderItem{
-		OrderID:    string(order.ID),
-		CustomerID: string(order.CustomerID),
-		TotalCents: order.TotalCents,
-		CreatedAt:  order.CreatedAt.Format(time.RFC3339),
-	}
+type OrderLineItem struct {
+	SKU            string `dynamodbav:"sku"`
+	Quantity       int64  `dynamodbav:"quantity"`
+	UnitPriceMinor int64  `dynamodbav:"unit_price_minor"`
+}
 
-	av, err := attributevalue.MarshalMap(item)
+func (r *DynamoDBReadModelRepository) SaveOrder(ctx context.Context, order *domain.Order) error {
+	av, err := newOrderItem(order)
 	if err != nil {
 		r.logger.Error("failed to marshal order", err)
-		return fmt.Errorf("marshal order: %w", err)
+		return err
 	}
 
+	put := newOrderPut(r.tableName, av, order.Version)
 	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: aws.String(r.tableName),
-		Item:      av,
+		TableName:                 put.TableName,
+		Item:                      put.Item,
+		ConditionExpression:       put.ConditionExpression,
+		ExpressionAttributeValues: put.ExpressionAttributeValues,
 	})
 
 	if err != nil {
+		if isConditionalCheckFailure(err) {
+			r.logger.Warn("stale read model write", map[string]interface{}{
+				"order_id": order.ID,
+				"version":  order.Version,
+			})
+			return domain.ErrStaleEvent
+		}
 		r.logger.Error("failed to save order", err, map[string]interface{}{
 			"order_id": order.ID,
 		})
@@ -67,6 +82,51 @@ func (r *DynamoDBReadModelRepository) SaveOrder(ctx context.Context, order *doma
 	return nil
 }
 
+func newOrderPut(tableName string, item map[string]types.AttributeValue, version int64) *types.Put {
+	put := &types.Put{
+		TableName: aws.String(tableName),
+		Item:      item,
+	}
+	if version > 0 {
+		// Rows written before the read model was versioned have no version
+		// attribute and must not block newer writes.
+		put.ConditionExpression = aws.String("attribute_not_exists(order_id) OR attribute_not_exists(version) OR version < :version")
+		put.ExpressionAttributeValues = map[string]types.AttributeValue{
+			":version": &types.AttributeValueMemberN{Value: strconv.FormatInt(version, 10)},
+		}
+	}
+	return put
+}
+
+func newOrderItem(order *domain.Order) (map[string]types.AttributeValue, error) {
+	items := make([]OrderLineItem, 0, len(order.Items))
+	for _, lineItem := range order.Items {
+		items = append(items, OrderLineItem{
+			SKU:            lineItem.SKU,
+			Quantity:       lineItem.Quantity,
+			UnitPriceMinor: lineItem.UnitPrice.Amount,
+		})
+	}
+
+	item := OrderItem{
+		OrderID:    string(order.ID),
+		CustomerID: string(order.CustomerID),
+		Items:      items,
+		Currency:   string(order.Total.Currency),
+		TotalCents: order.Total.Amount,
+		Status:     string(order.Status),
+		Version:    order.Version,
+		CreatedAt:  order.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  order.UpdatedAt.Format(time.RFC3339),
+	}
+
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return nil, fmt.Errorf("marshal order: %w", err)
+	}
+	return av, nil
+}
+
 func (r *DynamoDBReadModelRepository) GetOrder(ctx context.Context, orderID domain.OrderID) (*domain.Order, error) {
 	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: aws.String(r.tableName),
@@ -93,11 +153,38 @@ func (r *DynamoDBReadModelRepository) GetOrder(ctx context.Context, orderID doma
 	}
 
 	createdAt, _ := time.Parse(time.RFC3339, item.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, item.UpdatedAt)
+
+	status := domain.OrderStatus(item.Status)
+	if status == "" {
+		status = domain.OrderStatusCreated
+	}
+	version := item.Version
+	if version == 0 {
+		version = 1
+	}
+	currency := domain.Currency(item.Currency)
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+
+	lineItems := make([]domain.LineItem, 0, len(item.Items))
+	for _, lineItem := range item.Items {
+		lineItems = append(lineItems, domain.LineItem{
+			SKU:       lineItem.SKU,
+			Quantity:  lineItem.Quantity,
+			UnitPrice: domain.Money{Amount: lineItem.UnitPriceMinor, Currency: currency},
+		})
+	}
 
 	return &domain.Order{
 		ID:         domain.OrderID(item.OrderID),
 		CustomerID: domain.CustomerID(item.CustomerID),
-		TotalCents: item.TotalCents,
+		Items:      lineItems,
+		Total:      domain.Money{Amount: item.TotalCents, Currency: currency},
+		Status:     status,
+		Version:    version,
 		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
 	}, nil
 }
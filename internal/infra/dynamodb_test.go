@@ -0,0 +1,593 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+type fakeDynamoDB struct {
+	transactInputs []*dynamodb.TransactWriteItemsInput
+	transactErr    error
+	queryInputs    []*dynamodb.QueryInput
+	queryPages     []*dynamodb.QueryOutput
+	queryErr       error
+	items          map[string]map[string]types.AttributeValue
+	putInputs      []*dynamodb.PutItemInput
+	putErr         error
+	scanInputs     []*dynamodb.ScanInput
+	scanPages      []*dynamodb.ScanOutput
+}
+
+func newFakeDynamoDB() *fakeDynamoDB {
+	return &fakeDynamoDB{items: make(map[string]map[string]types.AttributeValue)}
+}
+
+func (f *fakeDynamoDB) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	f.transactInputs = append(f.transactInputs, params)
+	if f.transactErr != nil {
+		return nil, f.transactErr
+	}
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+func (f *fakeDynamoDB) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	f.queryInputs = append(f.queryInputs, params)
+	if f.queryErr != nil {
+		return nil, f.queryErr
+	}
+	return f.queryPages[len(f.queryInputs)-1], nil
+}
+
+func (f *fakeDynamoDB) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	f.putInputs = append(f.putInputs, params)
+	if f.putErr != nil {
+		return nil, f.putErr
+	}
+	f.items[itemKey(params.Item)] = params.Item
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (f *fakeDynamoDB) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	f.scanInputs = append(f.scanInputs, params)
+	return f.scanPages[len(f.scanInputs)-1], nil
+}
+
+func (f *fakeDynamoDB) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	return &dynamodb.GetItemOutput{Item: f.items[itemKey(params.Key)]}, nil
+}
+
+func itemKey(item map[string]types.AttributeValue) string {
+	for _, name := range []string{"order_id", "event_id"} {
+		if value, ok := item[name].(*types.AttributeValueMemberS); ok {
+			return value.Value
+		}
+	}
+	return ""
+}
+
+func transactionCanceled(codes ...string) error {
+	reasons := make([]types.CancellationReason, len(codes))
+	for i, code := range codes {
+		reasons[i] = types.CancellationReason{Code: aws.String(code)}
+	}
+	return &types.TransactionCanceledException{Message: aws.String("transaction cancelled"), CancellationReasons: reasons}
+}
+
+func TestDynamoDBEventRepositorySaveEvent(t *testing.T) {
+	order, err := domain.NewOrder("order-123", "customer-456", []domain.LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: domain.Money{Amount: 100, Currency: "EUR"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created := domain.NewOrderCreatedEvent("event-1", "corr-1", order)
+	confirmed, err := order.Confirm("event-2", "corr-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		event           *domain.Event
+		expectedVersion int64
+		transactErr     error
+		wantErr         bool
+		wantErrIs       error
+		wantTransact    bool
+	}{
+		{name: "saves event and outbox entry", event: created, expectedVersion: 0, wantTransact: true},
+		{name: "existing order", event: created, expectedVersion: 0, transactErr: transactionCanceled("ConditionalCheckFailed", "None"), wantErr: true, wantErrIs: domain.ErrOrderAlreadyExists, wantTransact: true},
+		{name: "concurrent append", event: confirmed, expectedVersion: 1, transactErr: transactionCanceled("ConditionalCheckFailed", "None"), wantErr: true, wantErrIs: domain.ErrConcurrencyConflict, wantTransact: true},
+		{name: "outbox clash is not a domain conflict", event: confirmed, expectedVersion: 1, transactErr: transactionCanceled("None", "ConditionalCheckFailed"), wantErr: true, wantTransact: true},
+		{name: "outbox clash on a new order is not a domain conflict", event: created, expectedVersion: 0, transactErr: transactionCanceled("None", "ConditionalCheckFailed"), wantErr: true, wantTransact: true},
+		{name: "transaction conflict is not a condition failure", event: confirmed, expectedVersion: 1, transactErr: transactionCanceled("TransactionConflict", "None"), wantErr: true, wantTransact: true},
+		{name: "request failure", event: confirmed, expectedVersion: 1, transactErr: errors.New("connection reset"), wantErr: true, wantTransact: true},
+		{name: "version gap is rejected locally", event: confirmed, expectedVersion: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeDynamoDB()
+			client.transactErr = tt.transactErr
+			repo := NewDynamoDBEventRepository(client, "events", "outbox", observability.NewLogger("", ""))
+
+			err := repo.SaveEvent(context.Background(), tt.event, tt.expectedVersion)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("expected %v, got %v", tt.wantErrIs, err)
+			}
+			if tt.wantErrIs == nil && (errors.Is(err, domain.ErrOrderAlreadyExists) || errors.Is(err, domain.ErrConcurrencyConflict)) {
+				t.Errorf("expected an infrastructure error, got %v", err)
+			}
+			if got := len(client.transactInputs) == 1; got != tt.wantTransact {
+				t.Fatalf("expected transaction %v, got %d calls", tt.wantTransact, len(client.transactInputs))
+			}
+			if !tt.wantTransact {
+				return
+			}
+
+			items := client.transactInputs[0].TransactItems
+			if len(items) != 2 {
+				t.Fatalf("expected event and outbox puts, got %d items", len(items))
+			}
+			eventPut, outboxPut := items[0].Put, items[1].Put
+			if aws.ToString(eventPut.TableName) != "events" || aws.ToString(eventPut.ConditionExpression) != "attribute_not_exists(order_id)" {
+				t.Errorf("unexpected event put: %s %s", aws.ToString(eventPut.TableName), aws.ToString(eventPut.ConditionExpression))
+			}
+			if aws.ToString(outboxPut.TableName) != "outbox" || aws.ToString(outboxPut.ConditionExpression) != "attribute_not_exists(event_id)" {
+				t.Errorf("unexpected outbox put: %s %s", aws.ToString(outboxPut.TableName), aws.ToString(outboxPut.ConditionExpression))
+			}
+			if v, _ := eventPut.Item["aggregate_version"].(*types.AttributeValueMemberN); v == nil || v.Value != strconv.FormatInt(tt.event.AggregateVersion, 10) {
+				t.Errorf("expected a numeric aggregate_version, got %#v", eventPut.Item["aggregate_version"])
+			}
+			if v, _ := outboxPut.Item["event_id"].(*types.AttributeValueMemberS); v == nil || v.Value != tt.event.EventID {
+				t.Errorf("expected outbox event_id %s, got %#v", tt.event.EventID, outboxPut.Item["event_id"])
+			}
+		})
+	}
+}
+
+func TestDynamoDBEventRepositoryGetEventsAfterVersion(t *testing.T) {
+	eventItem := func(version int64, eventType, data string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{
+			"order_id":          &types.AttributeValueMemberS{Value: "order-123"},
+			"aggregate_version": &types.AttributeValueMemberN{Value: strconv.FormatInt(version, 10)},
+			"event_id":          &types.AttributeValueMemberS{Value: "event-" + strconv.FormatInt(version, 10)},
+			"event_type":        &types.AttributeValueMemberS{Value: eventType},
+			"source":            &types.AttributeValueMemberS{Value: domain.EventSourceOrders},
+			"version":           &types.AttributeValueMemberS{Value: "1.0"},
+			"created_at":        &types.AttributeValueMemberS{Value: "2024-05-01T10:00:00.000Z"},
+			"data":              &types.AttributeValueMemberS{Value: data},
+		}
+	}
+
+	client := newFakeDynamoDB()
+	client.queryPages = []*dynamodb.QueryOutput{
+		{
+			Items:            []map[string]types.AttributeValue{eventItem(1, domain.EventTypeOrderCreated, `{"order_id":"order-123","customer_id":"customer-456","total_cents":100,"status":"CREATED"}`)},
+			LastEvaluatedKey: map[string]types.AttributeValue{"order_id": &types.AttributeValueMemberS{Value: "order-123"}},
+		},
+		{
+			Items: []map[string]types.AttributeValue{eventItem(2, domain.EventTypeOrderConfirmed, `{"order_id":"order-123","status":"CONFIRMED"}`)},
+		},
+	}
+	repo := NewDynamoDBEventRepository(client, "events", "outbox", observability.NewLogger("", ""))
+
+	events, err := repo.GetEventsAfterVersion(context.Background(), "order-123", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(client.queryInputs) != 2 || client.queryInputs[1].ExclusiveStartKey == nil {
+		t.Fatalf("expected a second page queried from the last evaluated key")
+	}
+	if !aws.ToBool(client.queryInputs[0].ConsistentRead) {
+		t.Errorf("expected a consistent read")
+	}
+	if len(events) != 2 || events[0].AggregateVersion != 1 || events[1].AggregateVersion != 2 {
+		t.Fatalf("expected events at versions 1 and 2, got %d events", len(events))
+	}
+	if events[0].Version != domain.EventVersionV2 {
+		t.Errorf("expected the created event to be upcasted, got version %s", events[0].Version)
+	}
+	if !events[0].CreatedAt.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected created_at %v", events[0].CreatedAt)
+	}
+
+	client = newFakeDynamoDB()
+	client.queryErr = errors.New("throttled")
+	repo = NewDynamoDBEventRepository(client, "events", "outbox", observability.NewLogger("", ""))
+	if _, err := repo.GetEventsAfterVersion(context.Background(), "order-123", 0); err == nil {
+		t.Error("expected the query error to be returned")
+	}
+}
+
+func TestDynamoDBProcessedEventsRepositoryMarkAsProcessed(t *testing.T) {
+	tests := []struct {
+		name    string
+		putErr  error
+		wantErr bool
+	}{
+		{name: "first delivery"},
+		{name: "duplicate delivery", putErr: &types.ConditionalCheckFailedException{Message: aws.String("exists")}},
+		{name: "request failure", putErr: errors.New("connection reset"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeDynamoDB()
+			client.putErr = tt.putErr
+			repo := NewDynamoDBProcessedEventsRepository(client, "processed", "projection", observability.NewLogger("", ""))
+
+			if err := repo.MarkAsProcessed(context.Background(), "event-1"); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDynamoDBProcessedEventsRepositoryLegacyKeys(t *testing.T) {
+	ctx := context.Background()
+	logger := observability.NewLogger("", "")
+	client := newFakeDynamoDB()
+	client.items["event-legacy"] = map[string]types.AttributeValue{"event_id": &types.AttributeValueMemberS{Value: "event-legacy"}}
+
+	tests := []struct {
+		name          string
+		repo          *DynamoDBProcessedEventsRepository
+		wantProcessed bool
+	}{
+		{name: "scoped consumer ignores bare keys", repo: NewDynamoDBProcessedEventsRepository(client, "processed", "notifier", logger)},
+		{name: "legacy consumer honours bare keys", repo: NewDynamoDBProcessedEventsRepositoryWithLegacyKeys(client, "processed", "projection", logger), wantProcessed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processed, err := tt.repo.IsProcessed(ctx, "event-legacy")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if processed != tt.wantProcessed {
+				t.Errorf("expected processed %v, got %v", tt.wantProcessed, processed)
+			}
+		})
+	}
+}
+
+func TestDynamoDBReadModelRepositoryRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	repo := NewDynamoDBReadModelRepository(newFakeDynamoDB(), "orders", observability.NewLogger("", ""))
+
+	if order, err := repo.GetOrder(ctx, "order-123"); err != nil || order != nil {
+		t.Fatalf("expected nil, nil for a missing order, got %v, %v", order, err)
+	}
+
+	order, err := domain.NewOrder("order-123", "customer-456", []domain.LineItem{{SKU: "sku-1", Quantity: 3, UnitPrice: domain.Money{Amount: 250, Currency: "USD"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.SaveOrder(ctx, order); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := repo.GetOrder(ctx, "order-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Total != order.Total || got.Status != order.Status || len(got.Items) != 1 || got.Items[0] != order.Items[0] {
+		t.Errorf("expected %+v, got %+v", order, got)
+	}
+}
+
+const orderVersionCondition = "attribute_not_exists(order_id) OR attribute_not_exists(version) OR version < :version"
+
+func TestDynamoDBReadModelRepositorySaveOrderVersionCondition(t *testing.T) {
+	order, err := domain.NewOrder("order-123", "customer-456", []domain.LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: domain.Money{Amount: 100, Currency: "EUR"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	unversioned := *order
+	unversioned.Version = 0
+
+	tests := []struct {
+		name          string
+		order         *domain.Order
+		putErr        error
+		wantCondition bool
+		wantErr       bool
+		wantErrIs     error
+	}{
+		{name: "newer version", order: order, wantCondition: true},
+		{name: "stale version", order: order, putErr: &types.ConditionalCheckFailedException{}, wantCondition: true, wantErr: true, wantErrIs: domain.ErrStaleEvent},
+		{name: "unversioned order is written unconditionally", order: &unversioned},
+		{name: "request failure", order: order, putErr: errors.New("connection reset"), wantCondition: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeDynamoDB()
+			client.putErr = tt.putErr
+			repo := NewDynamoDBReadModelRepository(client, "orders", observability.NewLogger("", ""))
+
+			err := repo.SaveOrder(context.Background(), tt.order)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("expected %v, got %v", tt.wantErrIs, err)
+			}
+			if tt.wantErrIs == nil && errors.Is(err, domain.ErrStaleEvent) {
+				t.Errorf("expected an infrastructure error, got %v", err)
+			}
+
+			put := client.putInputs[0]
+			if (put.ConditionExpression != nil) != tt.wantCondition {
+				t.Fatalf("expected condition %v, got %q", tt.wantCondition, aws.ToString(put.ConditionExpression))
+			}
+			if tt.wantCondition {
+				if aws.ToString(put.ConditionExpression) != orderVersionCondition {
+					t.Errorf("unexpected condition %q", aws.ToString(put.ConditionExpression))
+				}
+				if v, _ := put.ExpressionAttributeValues[":version"].(*types.AttributeValueMemberN); v == nil || v.Value != "1" {
+					t.Errorf("expected :version 1, got %#v", put.ExpressionAttributeValues[":version"])
+				}
+			}
+		})
+	}
+}
+
+func TestDynamoDBProjectionRepositorySaveOrderAndMarkProcessed(t *testing.T) {
+	order, err := domain.NewOrder("order-123", "customer-456", []domain.LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: domain.Money{Amount: 100, Currency: "EUR"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		transactErr error
+		wantErr     bool
+		wantErrIs   error
+	}{
+		{name: "first delivery"},
+		{name: "duplicate delivery", transactErr: transactionCanceled("None", "ConditionalCheckFailed"), wantErr: true, wantErrIs: domain.ErrEventAlreadyProcessed},
+		{name: "stale delivery", transactErr: transactionCanceled("ConditionalCheckFailed", "None"), wantErr: true, wantErrIs: domain.ErrStaleEvent},
+		{name: "stale duplicate is reported as a duplicate", transactErr: transactionCanceled("ConditionalCheckFailed", "ConditionalCheckFailed"), wantErr: true, wantErrIs: domain.ErrEventAlreadyProcessed},
+		{name: "request failure", transactErr: errors.New("connection reset"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeDynamoDB()
+			client.transactErr = tt.transactErr
+			logger := observability.NewLogger("", "")
+			repo := NewDynamoDBProjectionRepository(client, "orders", NewDynamoDBProcessedEventsRepository(client, "processed", "projection", logger), logger)
+
+			err := repo.SaveOrderAndMarkProcessed(context.Background(), order, "event-1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("expected %v, got %v", tt.wantErrIs, err)
+			}
+			if tt.wantErrIs == nil && (errors.Is(err, domain.ErrEventAlreadyProcessed) || errors.Is(err, domain.ErrStaleEvent)) {
+				t.Errorf("expected an infrastructure error, got %v", err)
+			}
+
+			items := client.transactInputs[0].TransactItems
+			if len(items) != 2 {
+				t.Fatalf("expected order and marker puts, got %d items", len(items))
+			}
+			orderPut, markerPut := items[0].Put, items[1].Put
+			if aws.ToString(orderPut.TableName) != "orders" || aws.ToString(orderPut.ConditionExpression) != orderVersionCondition {
+				t.Errorf("unexpected order put: %s %s", aws.ToString(orderPut.TableName), aws.ToString(orderPut.ConditionExpression))
+			}
+			if aws.ToString(markerPut.TableName) != "processed" || aws.ToString(markerPut.ConditionExpression) != "attribute_not_exists(event_id)" {
+				t.Errorf("unexpected marker put: %s %s", aws.ToString(markerPut.TableName), aws.ToString(markerPut.ConditionExpression))
+			}
+			if v, _ := markerPut.Item["event_id"].(*types.AttributeValueMemberS); v == nil || v.Value != "projection#event-1" {
+				t.Errorf("expected the marker to be keyed per consumer, got %#v", markerPut.Item["event_id"])
+			}
+			if _, ok := markerPut.Item["ttl"]; !ok {
+				t.Errorf("expected the marker to carry a ttl")
+			}
+		})
+	}
+}
+
+func TestDynamoDBProjectionRepositoryLegacyKeys(t *testing.T) {
+	order, err := domain.NewOrder("order-123", "customer-456", []domain.LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: domain.Money{Amount: 100, Currency: "EUR"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		transactErr error
+		wantErrIs   error
+	}{
+		{name: "no legacy marker"},
+		{name: "legacy marker exists", transactErr: transactionCanceled("None", "None", "ConditionalCheckFailed"), wantErrIs: domain.ErrEventAlreadyProcessed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeDynamoDB()
+			client.transactErr = tt.transactErr
+			logger := observability.NewLogger("", "")
+			repo := NewDynamoDBProjectionRepository(client, "orders", NewDynamoDBProcessedEventsRepositoryWithLegacyKeys(client, "processed", "projection", logger), logger)
+
+			err := repo.SaveOrderAndMarkProcessed(context.Background(), order, "event-1")
+			if !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("expected %v, got %v", tt.wantErrIs, err)
+			}
+
+			items := client.transactInputs[0].TransactItems
+			if len(items) != 3 || items[2].ConditionCheck == nil {
+				t.Fatalf("expected a condition check on the legacy marker, got %d items", len(items))
+			}
+			check := items[2].ConditionCheck
+			if v, _ := check.Key["event_id"].(*types.AttributeValueMemberS); v == nil || v.Value != "event-1" {
+				t.Errorf("expected the check on the bare event id, got %#v", check.Key["event_id"])
+			}
+			if aws.ToString(check.TableName) != "processed" || aws.ToString(check.ConditionExpression) != "attribute_not_exists(event_id)" {
+				t.Errorf("unexpected condition check: %s %s", aws.ToString(check.TableName), aws.ToString(check.ConditionExpression))
+			}
+		})
+	}
+}
+
+func TestDynamoDBProjectionRepositoryWithOverwrite(t *testing.T) {
+	order, err := domain.NewOrder("order-123", "customer-456", []domain.LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: domain.Money{Amount: 100, Currency: "EUR"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := newFakeDynamoDB()
+	logger := observability.NewLogger("", "")
+	repo := NewDynamoDBProjectionRepositoryWithOverwrite(client, "orders", NewDynamoDBProcessedEventsRepository(client, "processed", "replay", logger), logger)
+
+	if err := repo.SaveOrderAndMarkProcessed(context.Background(), order, "event-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	items := client.transactInputs[0].TransactItems
+	if items[0].Put.ConditionExpression != nil {
+		t.Errorf("expected the order to be written without a version check, got %q", aws.ToString(items[0].Put.ConditionExpression))
+	}
+	if aws.ToString(items[1].Put.ConditionExpression) != "attribute_not_exists(event_id)" {
+		t.Errorf("expected the marker to stay conditional, got %q", aws.ToString(items[1].Put.ConditionExpression))
+	}
+}
+
+func TestEventStoreMigration(t *testing.T) {
+	legacy := func(eventID, orderID, createdAt string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{
+			"event_id":   &types.AttributeValueMemberS{Value: eventID},
+			"order_id":   &types.AttributeValueMemberS{Value: orderID},
+			"event_type": &types.AttributeValueMemberS{Value: domain.EventTypeOrderCreated},
+			"created_at": &types.AttributeValueMemberS{Value: createdAt},
+		}
+	}
+
+	t.Run("numbers each order stream by creation time", func(t *testing.T) {
+		client := newFakeDynamoDB()
+		client.scanPages = []*dynamodb.ScanOutput{
+			{
+				Items: []map[string]types.AttributeValue{
+					legacy("event-b", "order-1", "2024-01-01T10:00:05.000Z"),
+					legacy("event-c", "order-2", "2024-01-01T09:00:00.000Z"),
+				},
+				LastEvaluatedKey: map[string]types.AttributeValue{"event_id": &types.AttributeValueMemberS{Value: "event-c"}},
+			},
+			{Items: []map[string]types.AttributeValue{legacy("event-a", "order-1", "2024-01-01T10:00:00.000Z")}},
+		}
+		migration := NewEventStoreMigration(client, "event_store", "event_store_v2", observability.NewLogger("", ""))
+
+		result, err := migration.Migrate(context.Background(), false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *result != (EventStoreMigrationResult{Scanned: 3, Orders: 2, Copied: 3}) {
+			t.Errorf("unexpected result %+v", *result)
+		}
+		if v, _ := client.scanInputs[1].ExclusiveStartKey["event_id"].(*types.AttributeValueMemberS); v == nil || v.Value != "event-c" {
+			t.Errorf("expected the second page to start after event-c")
+		}
+
+		want := []struct {
+			eventID string
+			version string
+		}{{"event-a", "1"}, {"event-b", "2"}, {"event-c", "1"}}
+		for i, w := range want {
+			put := client.putInputs[i]
+			eventID, _ := put.Item["event_id"].(*types.AttributeValueMemberS)
+			version, _ := put.Item["aggregate_version"].(*types.AttributeValueMemberN)
+			if eventID == nil || eventID.Value != w.eventID || version == nil || version.Value != w.version {
+				t.Errorf("put %d: expected %s at version %s, got %#v", i, w.eventID, w.version, put.Item)
+			}
+			if aws.ToString(put.TableName) != "event_store_v2" || aws.ToString(put.ConditionExpression) != "attribute_not_exists(order_id)" {
+				t.Errorf("put %d: unexpected target %s %s", i, aws.ToString(put.TableName), aws.ToString(put.ConditionExpression))
+			}
+		}
+	})
+
+	tests := []struct {
+		name        string
+		existing    string
+		wantErr     bool
+		wantSkipped int
+	}{
+		{name: "rerun skips copied events", existing: "event-a", wantSkipped: 1},
+		{name: "conflicting event aborts", existing: "event-x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeDynamoDB()
+			client.scanPages = []*dynamodb.ScanOutput{{Items: []map[string]types.AttributeValue{legacy("event-a", "order-1", "2024-01-01T10:00:00.000Z")}}}
+			client.putErr = &types.ConditionalCheckFailedException{Item: legacy(tt.existing, "order-1", "2024-01-01T10:00:00.000Z")}
+			migration := NewEventStoreMigration(client, "event_store", "event_store_v2", observability.NewLogger("", ""))
+
+			result, err := migration.Migrate(context.Background(), false)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if result.Skipped != tt.wantSkipped || result.Copied != 0 {
+				t.Errorf("unexpected result %+v", *result)
+			}
+		})
+	}
+}
+
+func TestDynamoDBEventScannerScanEvents(t *testing.T) {
+	ctx := context.Background()
+	eventKey := func(orderID string, version int64) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{
+			"order_id":          &types.AttributeValueMemberS{Value: orderID},
+			"aggregate_version": &types.AttributeValueMemberN{Value: strconv.FormatInt(version, 10)},
+		}
+	}
+	item := eventKey("order-123", 1)
+	item["event_id"] = &types.AttributeValueMemberS{Value: "event-1"}
+	item["event_type"] = &types.AttributeValueMemberS{Value: domain.EventTypeOrderCreated}
+	item["version"] = &types.AttributeValueMemberS{Value: domain.EventVersionV1}
+
+	client := newFakeDynamoDB()
+	client.scanPages = []*dynamodb.ScanOutput{
+		{Items: []map[string]types.AttributeValue{item}, LastEvaluatedKey: eventKey("order-123", 1)},
+		{},
+	}
+	scanner := NewDynamoDBEventScanner(client, "events", observability.NewLogger("", ""))
+
+	events, cursor, err := scanner.ScanEvents(ctx, "", 25)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 || events[0].EventID != "event-1" || events[0].Version != domain.EventVersionV1 {
+		t.Fatalf("expected event-1 as stored, got %+v", events)
+	}
+	if client.scanInputs[0].ExclusiveStartKey != nil || aws.ToInt32(client.scanInputs[0].Limit) != 25 {
+		t.Errorf("unexpected first scan input: %+v", client.scanInputs[0])
+	}
+
+	events, next, err := scanner.ScanEvents(ctx, cursor, 25)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 0 || next != "" {
+		t.Errorf("expected the scan to be complete, got %d events and cursor %q", len(events), next)
+	}
+	startKey := client.scanInputs[1].ExclusiveStartKey
+	if v, _ := startKey["aggregate_version"].(*types.AttributeValueMemberN); v == nil || v.Value != "1" || itemKey(startKey) != "order-123" {
+		t.Errorf("expected the scan to resume after order-123 version 1, got %#v", startKey)
+	}
+
+	if _, _, err := scanner.ScanEvents(ctx, "not a cursor", 25); err == nil {
+		t.Error("expected an invalid cursor to be rejected")
+	}
+}
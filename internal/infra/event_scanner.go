@@ -0,0 +1,98 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+// eventCursor is the position after the last event of a scan page. It is
+// serialised as JSON so checkpoints stay readable.
+type eventCursor struct {
+	OrderID          string `json:"order_id" dynamodbav:"order_id"`
+	AggregateVersion int64  `json:"aggregate_version" dynamodbav:"aggregate_version"`
+}
+
+func parseEventCursor(cursor string) (*eventCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	var c eventCursor
+	if err := json.Unmarshal([]byte(cursor), &c); err != nil || c.OrderID == "" {
+		return nil, fmt.Errorf("invalid event cursor %q", cursor)
+	}
+	return &c, nil
+}
+
+func (c eventCursor) String() string {
+	encoded, _ := json.Marshal(c)
+	return string(encoded)
+}
+
+type DynamoDBEventScanner struct {
+	client    DynamoDBScanAPI
+	tableName string
+	logger    *observability.Logger
+}
+
+func NewDynamoDBEventScanner(client DynamoDBScanAPI, tableName string, logger *observability.Logger) *DynamoDBEventScanner {
+	return &DynamoDBEventScanner{
+		client:    client,
+		tableName: tableName,
+		logger:    logger,
+	}
+}
+
+func (s *DynamoDBEventScanner) ScanEvents(ctx context.Context, cursor string, limit int) ([]*domain.Event, string, error) {
+	start, err := parseEventCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	input := &dynamodb.ScanInput{
+		TableName:      aws.String(s.tableName),
+		Limit:          aws.Int32(int32(limit)),
+		ConsistentRead: aws.Bool(true),
+	}
+	if start != nil {
+		input.ExclusiveStartKey = map[string]types.AttributeValue{
+			"order_id":          &types.AttributeValueMemberS{Value: start.OrderID},
+			"aggregate_version": &types.AttributeValueMemberN{Value: strconv.FormatInt(start.AggregateVersion, 10)},
+		}
+	}
+
+	result, err := s.client.Scan(ctx, input)
+	if err != nil {
+		s.logger.Error("failed to scan events", err, map[string]interface{}{
+			"cursor": cursor,
+		})
+		return nil, "", fmt.Errorf("scan events: %w", err)
+	}
+
+	events := make([]*domain.Event, 0, len(result.Items))
+	for _, item := range result.Items {
+		var eventItem EventItem
+		if err := attributevalue.UnmarshalMap(item, &eventItem); err != nil {
+			s.logger.Error("failed to unmarshal event", err)
+			return nil, "", fmt.Errorf("unmarshal event: %w", err)
+		}
+		events = append(events, eventItem.toDomain())
+	}
+
+	if len(result.LastEvaluatedKey) == 0 {
+		return events, "", nil
+	}
+	var next eventCursor
+	if err := attributevalue.UnmarshalMap(result.LastEvaluatedKey, &next); err != nil {
+		return nil, "", fmt.Errorf("unmarshal scan position: %w", err)
+	}
+	return events, next.String(), nil
+}
@@ -0,0 +1,113 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+type EventSourcedOrderRepository struct {
+	eventRepo     EventRepository
+	snapshotRepo  SnapshotRepository
+	snapshotEvery int
+	logger        *observability.Logger
+}
+
+func NewEventSourcedOrderRepository(eventRepo EventRepository, logger *observability.Logger) *EventSourcedOrderRepository {
+	return &EventSourcedOrderRepository{
+		eventRepo: eventRepo,
+		logger:    logger,
+	}
+}
+
+func NewEventSourcedOrderRepositoryWithSnapshots(eventRepo EventRepository, snapshotRepo SnapshotRepository, snapshotEvery int, logger *observability.Logger) *EventSourcedOrderRepository {
+	return &EventSourcedOrderRepository{
+		eventRepo:     eventRepo,
+		snapshotRepo:  snapshotRepo,
+		snapshotEvery: snapshotEvery,
+		logger:        logger,
+	}
+}
+
+func (r *EventSourcedOrderRepository) LoadOrder(ctx context.Context, orderID domain.OrderID) (*domain.Order, error) {
+	snapshot := r.loadSnapshot(ctx, orderID)
+
+	var afterVersion int64
+	if snapshot != nil {
+		afterVersion = snapshot.Version
+	}
+
+	events, err := r.eventRepo.GetEventsAfterVersion(ctx, orderID, afterVersion)
+	if err != nil {
+		return nil, fmt.Errorf("load order events: %w", err)
+	}
+
+	var order *domain.Order
+	if snapshot != nil {
+		order, err = domain.LoadFromSnapshot(snapshot, events)
+	} else {
+		order, err = domain.LoadFromHistory(events)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("failed to rehydrate order", err, map[string]interface{}{
+			"order_id": orderID,
+		})
+		return nil, fmt.Errorf("rehydrate order: %w", err)
+	}
+
+	// Snapshots are taken here rather than when an event is saved: SaveEvent
+	// only sees the new event, while a load has the rehydrated order and the
+	// number of events replayed since the last snapshot. Every command loads
+	// the order first, so a long stream is snapshotted by the next command.
+	// The write is best effort and conditional on the version, so concurrent
+	// loads cannot replace a newer snapshot.
+	if r.snapshotRepo != nil && r.snapshotEvery > 0 && len(events) >= r.snapshotEvery {
+		r.saveSnapshot(ctx, order)
+	}
+
+	return order, nil
+}
+
+func (r *EventSourcedOrderRepository) loadSnapshot(ctx context.Context, orderID domain.OrderID) *domain.OrderSnapshot {
+	if r.snapshotRepo == nil {
+		return nil
+	}
+
+	snapshot, err := r.snapshotRepo.GetLatestSnapshot(ctx, orderID)
+	if err != nil {
+		r.logger.Warn("failed to load snapshot, replaying full stream", map[string]interface{}{
+			"order_id": orderID,
+			"error":    err.Error(),
+		})
+		return nil
+	}
+
+	if snapshot != nil && snapshot.SchemaVersion != domain.OrderSnapshotSchemaVersion {
+		r.logger.Debug("discarding snapshot with outdated schema", map[string]interface{}{
+			"order_id":       orderID,
+			"schema_version": snapshot.SchemaVersion,
+		})
+		return nil
+	}
+
+	return snapshot
+}
+
+func (r *EventSourcedOrderRepository) saveSnapshot(ctx context.Context, order *domain.Order) {
+	snapshot, err := domain.NewOrderSnapshot(order)
+	if err == nil {
+		err = r.snapshotRepo.SaveSnapshot(ctx, snapshot)
+	}
+	if err != nil {
+		r.logger.Warn("failed to save snapshot", map[string]interface{}{
+			"order_id": order.ID,
+			"error":    err.Error(),
+		})
+	}
+}
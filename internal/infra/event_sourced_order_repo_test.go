@@ -0,0 +1,86 @@
+package infra
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+func TestEventSourcedOrderRepositorySnapshots(t *testing.T) {
+	eventItem := func(version int64, eventType, data string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{
+			"order_id":          &types.AttributeValueMemberS{Value: "order-123"},
+			"aggregate_version": &types.AttributeValueMemberN{Value: strconv.FormatInt(version, 10)},
+			"event_id":          &types.AttributeValueMemberS{Value: "event-" + strconv.FormatInt(version, 10)},
+			"event_type":        &types.AttributeValueMemberS{Value: eventType},
+			"source":            &types.AttributeValueMemberS{Value: domain.EventSourceOrders},
+			"version":           &types.AttributeValueMemberS{Value: "1.0"},
+			"created_at":        &types.AttributeValueMemberS{Value: "2024-05-01T10:00:00.000Z"},
+			"data":              &types.AttributeValueMemberS{Value: data},
+		}
+	}
+	stream := &dynamodb.QueryOutput{
+		Items: []map[string]types.AttributeValue{
+			eventItem(1, domain.EventTypeOrderCreated, `{"event_id":"event-1","order_id":"order-123","customer_id":"customer-456","items":[{"sku":"sku-1","quantity":1,"unit_price_minor":100}],"currency":"EUR","total_cents":100,"created_at":"2024-05-01T10:00:00Z","version":"1.0","aggregate_version":1}`),
+			eventItem(2, domain.EventTypeOrderConfirmed, `{"event_id":"event-2","order_id":"order-123","status":"CONFIRMED","occurred_at":"2024-05-01T10:05:00Z","version":"1.0","aggregate_version":2}`),
+		},
+	}
+	logger := observability.NewLogger("", "")
+
+	tests := []struct {
+		name          string
+		snapshotEvery int
+		wantSnapshots int
+	}{
+		{name: "disabled by default", snapshotEvery: 0, wantSnapshots: 0},
+		{name: "stream shorter than the threshold", snapshotEvery: 3, wantSnapshots: 0},
+		{name: "stream reaching the threshold", snapshotEvery: 2, wantSnapshots: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeDynamoDB()
+			client.queryPages = []*dynamodb.QueryOutput{stream, {}}
+			repo := NewEventSourcedOrderRepositoryWithSnapshots(
+				NewDynamoDBEventRepository(client, "events", "outbox", logger),
+				NewDynamoDBSnapshotRepository(client, "snapshots", logger),
+				tt.snapshotEvery,
+				logger,
+			)
+
+			order, err := repo.LoadOrder(context.Background(), "order-123")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if order == nil || order.Version != 2 || order.Status != domain.OrderStatusConfirmed {
+				t.Fatalf("expected the confirmed order at version 2, got %+v", order)
+			}
+			if len(client.putInputs) != tt.wantSnapshots {
+				t.Fatalf("expected %d snapshot writes, got %d", tt.wantSnapshots, len(client.putInputs))
+			}
+			if tt.wantSnapshots == 0 {
+				return
+			}
+
+			order, err = repo.LoadOrder(context.Background(), "order-123")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if order == nil || order.Version != 2 || order.Status != domain.OrderStatusConfirmed {
+				t.Fatalf("expected the order restored from the snapshot, got %+v", order)
+			}
+			after, _ := client.queryInputs[1].ExpressionAttributeValues[":after_version"].(*types.AttributeValueMemberN)
+			if after == nil || after.Value != "2" {
+				t.Errorf("expected the reload to query after the snapshot version, got %+v", after)
+			}
+			if len(client.putInputs) != 1 {
+				t.Errorf("expected no snapshot for a reload without new events, got %d writes", len(client.putInputs))
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+type EventStoreMigrationResult struct {
+	Scanned int
+	Orders  int
+	Copied  int
+	Skipped int
+}
+
+// EventStoreMigration copies events from the legacy event store, keyed by
+// event_id only, into the table keyed by order_id and aggregate_version.
+// Versions are assigned per order in created_at order, ties broken by event_id.
+// The whole source table is held in memory so every order stream is complete
+// before it is numbered. Writes are conditional, so an interrupted migration
+// can be rerun; events copied by an earlier run are skipped.
+type EventStoreMigration struct {
+	client      DynamoDBMigrationAPI
+	sourceTable string
+	targetTable string
+	logger      *observability.Logger
+}
+
+func NewEventStoreMigration(client DynamoDBMigrationAPI, sourceTable, targetTable string, logger *observability.Logger) *EventStoreMigration {
+	return &EventStoreMigration{
+		client:      client,
+		sourceTable: sourceTable,
+		targetTable: targetTable,
+		logger:      logger,
+	}
+}
+
+func (m *EventStoreMigration) Migrate(ctx context.Context, dryRun bool) (*EventStoreMigrationResult, error) {
+	result := &EventStoreMigrationResult{}
+
+	streams := make(map[string][]EventItem)
+	var startKey map[string]types.AttributeValue
+	for {
+		page, err := m.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:         aws.String(m.sourceTable),
+			ExclusiveStartKey: startKey,
+			ConsistentRead:    aws.Bool(true),
+		})
+		if err != nil {
+			m.logger.Error("failed to scan legacy event store", err, map[string]interface{}{
+				"table": m.sourceTable,
+			})
+			return result, fmt.Errorf("scan legacy events: %w", err)
+		}
+		for _, av := range page.Items {
+			var item EventItem
+			if err := attributevalue.UnmarshalMap(av, &item); err != nil {
+				return result, fmt.Errorf("unmarshal legacy event: %w", err)
+			}
+			streams[item.OrderID] = append(streams[item.OrderID], item)
+			result.Scanned++
+		}
+		if len(page.LastEvaluatedKey) == 0 {
+			break
+		}
+		startKey = page.LastEvaluatedKey
+	}
+
+	orderIDs := make([]string, 0, len(streams))
+	for orderID := range streams {
+		orderIDs = append(orderIDs, orderID)
+	}
+	sort.Strings(orderIDs)
+	result.Orders = len(orderIDs)
+
+	for _, orderID := range orderIDs {
+		stream := streams[orderID]
+		sort.SliceStable(stream, func(i, j int) bool {
+			if stream[i].CreatedAt != stream[j].CreatedAt {
+				return stream[i].CreatedAt < stream[j].CreatedAt
+			}
+			return stream[i].EventID < stream[j].EventID
+		})
+		for i := range stream {
+			stream[i].AggregateVersion = int64(i + 1)
+			if dryRun {
+				continue
+			}
+			copied, err := m.copyEvent(ctx, stream[i])
+			if err != nil {
+				return result, err
+			}
+			if copied {
+				result.Copied++
+			} else {
+				result.Skipped++
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (m *EventStoreMigration) copyEvent(ctx context.Context, item EventItem) (bool, error) {
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return false, fmt.Errorf("marshal event: %w", err)
+	}
+
+	_, err = m.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                           aws.String(m.targetTable),
+		Item:                                av,
+		ConditionExpression:                 aws.String("attribute_not_exists(order_id)"),
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	})
+	if err == nil {
+		return true, nil
+	}
+
+	var condErr *types.ConditionalCheckFailedException
+	if !errors.As(err, &condErr) {
+		m.logger.Error("failed to copy event", err, map[string]interface{}{
+			"event_id": item.EventID,
+			"order_id": item.OrderID,
+		})
+		return false, fmt.Errorf("copy event: %w", err)
+	}
+
+	var existing EventItem
+	if err := attributevalue.UnmarshalMap(condErr.Item, &existing); err != nil || existing.EventID != item.EventID {
+		return false, fmt.Errorf("order %s already has a different event at version %d in %s", item.OrderID, item.AggregateVersion, m.targetTable)
+	}
+	return false, nil
+}
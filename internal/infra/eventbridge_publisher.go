@@ -2,60 +2,178 @@ package infra
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
 	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
-	"github.com/stevenbode/go-serverless-event-platform/internal/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
 	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
 )
 
+const (
+	putEventsMaxEntries      = 10
+	putEventsMaxRequestBytes = 256 * 1024
+)
+
+var retriableEntryErrorCodes = map[string]bool{
+	"ThrottlingException":    true,
+	"InternalFailure":        true,
+	"InternalException":      true,
+	"ServiceUnavailable":     true,
+	"ConcurrentModification": true,
+	"LimitExceededException": true,
+}
+
 type EventBridgePublisher struct {
-	client  *eventbridge.Client
-	busName string
-	logger  *observability.Logger
+	client      EventBridgeAPI
+	busName     string
+	eventTypes  *domain.EventTypeRegistry
+	format      domain.EventFormat
+	logger      *observability.Logger
+	maxAttempts int
+	backoff     time.Duration
+	concurrency int
+}
+
+func NewEventBridgePublisher(client EventBridgeAPI, busName string, logger *observability.Logger) *EventBridgePublisher {
+	return NewEventBridgePublisherWithRegistry(client, busName, domain.DefaultEventTypes(), domain.EventFormatEventBridge, logger)
+}
+
+func NewEventBridgePublisherWithFormat(client EventBridgeAPI, busName string, format domain.EventFormat, logger *observability.Logger) *EventBridgePublisher {
+	return NewEventBridgePublisherWithRegistry(client, busName, domain.DefaultEventTypes(), format, logger)
 }
 
-func NewEventBridgePublisher(client *eventbridge.Client, busName string, logger *observability.Logger) *EventBridgePublisher {
+func NewEventBridgePublisherWithRegistry(client EventBridgeAPI, busName string, eventTypes *domain.EventTypeRegistry, format domain.EventFormat, logger *observability.Logger) *EventBridgePublisher {
 	return &EventBridgePublisher{
-		client:  client,
-		busName: busName,
-		logger:  logger,
+		client:      client,
+		busName:     busName,
+		eventTypes:  eventTypes,
+		format:      format,
+		logger:      logger,
+		maxAttempts: defaultPublishMaxAttempts,
+		backoff:     defaultPublishBackoff,
+		concurrency: defaultPublishConcurrency,
 	}
 }
 
 func (p *EventBridgePublisher) PublishEvent(ctx context.Context, event *domain.Event) error {
-	detail := event.ToEventBridgeDetail()
-	detailJSON, err := json.Marshal(detail)
-	if err != nil {
-		p.logger.Error("failed to marshal event detail", err)
-		return fmt.Errorf("marshal event detail: %w", err)
-	}
-
-	_, err = p.client.PutEvents(ctx, &eventbridge.PutEventsInput{
-		Entries: []types.PutEventsRequestEntry{
-			{
-				Source:       aws.String(event.Source),
-				DetailType:   aws.String(event.EventType),
-				Detail:       aws.String(string(detailJSON)),
-				EventBusName: aws.String(p.busName),
-			},
-		},
+	return firstResultError(p.PublishEvents(ctx, []*domain.Event{event}))
+}
+
+func (p *EventBridgePublisher) PublishEvents(ctx context.Context, events []*domain.Event) []PublishResult {
+	results := make([]PublishResult, len(events))
+	entries := make([]types.PutEventsRequestEntry, 0, len(events))
+	positions := make([]int, 0, len(events))
+
+	for i, event := range events {
+		results[i].Event = event
+		entry, err := p.buildEntry(event)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		entries = append(entries, entry)
+		positions = append(positions, i)
+	}
+
+	chunks, oversized := chunkEntries(entries, putEventsMaxEntries, putEventsMaxRequestBytes)
+	for _, idx := range oversized {
+		results[positions[idx]].Err = oversizedEventError(putEventsMaxRequestBytes)
+	}
+
+	forEachConcurrently(len(chunks), p.concurrency, func(c int) {
+		chunk := chunks[c]
+		batch := make([]types.PutEventsRequestEntry, len(chunk))
+		for i, idx := range chunk {
+			batch[i] = entries[idx]
+		}
+		for i, err := range p.putEntries(ctx, batch) {
+			results[positions[chunk[i]]].Err = err
+		}
 	})
 
+	logPublishResults(p.logger, "eventbridge", results)
+
+	return results
+}
+
+func (p *EventBridgePublisher) buildEntry(event *domain.Event) (types.PutEventsRequestEntry, error) {
+	encoded, err := encodeForPublish(p.eventTypes, p.format, event, p.logger)
 	if err != nil {
-		p.logger.Error("failed to publish event", err, map[string]interface{}{
-			"event_id": event.EventID,
-		})
-		return fmt.Errorf("publish event: %w", err)
+		return types.PutEventsRequestEntry{}, err
 	}
 
-	p.logger.Info("event published", map[string]interface{}{
-		"event_id": event.EventID,
-		"order_id": event.OrderID,
+	return types.PutEventsRequestEntry{
+		Source:       aws.String(event.Source),
+		DetailType:   aws.String(event.EventType),
+		Detail:       aws.String(string(encoded)),
+		EventBusName: aws.String(p.busName),
+	}, nil
+}
+
+func (p *EventBridgePublisher) putEntries(ctx context.Context, entries []types.PutEventsRequestEntry) []error {
+	return sendWithRetry(ctx, len(entries), p.maxAttempts, p.backoff, p.logger, func(ctx context.Context, pending []int) []error {
+		batch := make([]types.PutEventsRequestEntry, len(pending))
+		for i, idx := range pending {
+			batch[i] = entries[idx]
+		}
+
+		errs := make([]error, len(pending))
+		output, err := p.client.PutEvents(ctx, &eventbridge.PutEventsInput{
+			Entries: batch,
+		})
+		if err != nil {
+			requestErr := domain.NewRetriableError(err, "put events request failed")
+			for i := range errs {
+				errs[i] = requestErr
+			}
+			return errs
+		}
+
+		for i := range pending {
+			errs[i] = entryResultError(output, i)
+		}
+		return errs
 	})
+}
 
-	return nil
+func entryResultError(output *eventbridge.PutEventsOutput, i int) error {
+	if output == nil || i >= len(output.Entries) {
+		return domain.NewRetriableError(fmt.Errorf("no result for entry %d", i), "put events entry failed")
+	}
+	result := output.Entries[i]
+	if result.ErrorCode == nil {
+		return nil
+	}
+	return classifyEntryError(aws.ToString(result.ErrorCode), aws.ToString(result.ErrorMessage))
+}
+
+func classifyEntryError(code, message string) error {
+	err := fmt.Errorf("put events entry failed: %s: %s", code, message)
+	if retriableEntryErrorCodes[code] {
+		return domain.NewRetriableError(err, "put events entry failed")
+	}
+	return domain.NewNonRetriableError(err, "put events entry rejected")
+}
+
+func chunkEntries(entries []types.PutEventsRequestEntry, maxEntries, maxBytes int) ([][]int, []int) {
+	sizes := make([]int, len(entries))
+	for i, entry := range entries {
+		sizes[i] = putEventsEntrySize(entry)
+	}
+	return chunkBySize(sizes, maxEntries, maxBytes)
+}
+
+// Mirrors the entry size calculation documented for PutEvents.
+func putEventsEntrySize(entry types.PutEventsRequestEntry) int {
+	size := len(aws.ToString(entry.Source)) + len(aws.ToString(entry.DetailType)) + len(aws.ToString(entry.Detail))
+	if entry.Time != nil {
+		size += 14
+	}
+	for _, resource := range entry.Resources {
+		size += len(resource)
+	}
+	return size
 }
@@ -0,0 +1,184 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+type fakeEventBridge struct {
+	inputs    []*eventbridge.PutEventsInput
+	responses []func(input *eventbridge.PutEventsInput) (*eventbridge.PutEventsOutput, error)
+}
+
+func (f *fakeEventBridge) PutEvents(ctx context.Context, params *eventbridge.PutEventsInput, optFns ...func(*eventbridge.Options)) (*eventbridge.PutEventsOutput, error) {
+	f.inputs = append(f.inputs, params)
+	respond := f.responses[len(f.inputs)-1]
+	return respond(params)
+}
+
+func allEntriesSucceed(input *eventbridge.PutEventsInput) (*eventbridge.PutEventsOutput, error) {
+	entries := make([]types.PutEventsResultEntry, len(input.Entries))
+	for i := range entries {
+		entries[i] = types.PutEventsResultEntry{EventId: aws.String("id")}
+	}
+	return &eventbridge.PutEventsOutput{Entries: entries}, nil
+}
+
+func firstEntryFails(code string) func(input *eventbridge.PutEventsInput) (*eventbridge.PutEventsOutput, error) {
+	return func(input *eventbridge.PutEventsInput) (*eventbridge.PutEventsOutput, error) {
+		output, _ := allEntriesSucceed(input)
+		output.FailedEntryCount = 1
+		output.Entries[0] = types.PutEventsResultEntry{ErrorCode: aws.String(code), ErrorMessage: aws.String(code)}
+		return output, nil
+	}
+}
+
+func TestEntryResultError(t *testing.T) {
+	output := &eventbridge.PutEventsOutput{
+		FailedEntryCount: 2,
+		Entries: []types.PutEventsResultEntry{
+			{EventId: aws.String("id-1")},
+			{ErrorCode: aws.String("ThrottlingException")},
+			{ErrorCode: aws.String("MalformedDetail")},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		index         int
+		wantErr       bool
+		wantRetriable bool
+	}{
+		{name: "published entry", index: 0},
+		{name: "throttled entry is retriable", index: 1, wantErr: true, wantRetriable: true},
+		{name: "rejected entry is not retriable", index: 2, wantErr: true},
+		{name: "missing result is retriable", index: 3, wantErr: true, wantRetriable: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := entryResultError(output, tt.index)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && domain.IsRetriable(err) != tt.wantRetriable {
+				t.Errorf("expected retriable %v, got %v", tt.wantRetriable, domain.IsRetriable(err))
+			}
+		})
+	}
+}
+
+func TestChunkEntries(t *testing.T) {
+	entryOfSize := func(detailBytes int) types.PutEventsRequestEntry {
+		return types.PutEventsRequestEntry{Detail: aws.String(strings.Repeat("x", detailBytes))}
+	}
+	repeat := func(n, detailBytes int) []types.PutEventsRequestEntry {
+		entries := make([]types.PutEventsRequestEntry, n)
+		for i := range entries {
+			entries[i] = entryOfSize(detailBytes)
+		}
+		return entries
+	}
+
+	tests := []struct {
+		name          string
+		entries       []types.PutEventsRequestEntry
+		wantChunks    []int
+		wantOversized int
+	}{
+		{name: "empty", entries: nil},
+		{name: "splits on entry count", entries: repeat(23, 10), wantChunks: []int{10, 10, 3}},
+		{name: "splits on request size", entries: repeat(4, 100*1024), wantChunks: []int{2, 2}},
+		{name: "oversized entries are rejected", entries: append(repeat(2, 10), entryOfSize(300*1024)), wantChunks: []int{2}, wantOversized: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks, oversized := chunkEntries(tt.entries, putEventsMaxEntries, putEventsMaxRequestBytes)
+			if len(oversized) != tt.wantOversized {
+				t.Errorf("expected %d oversized entries, got %d", tt.wantOversized, len(oversized))
+			}
+			if len(chunks) != len(tt.wantChunks) {
+				t.Fatalf("expected %d chunks, got %d", len(tt.wantChunks), len(chunks))
+			}
+			for i, chunk := range chunks {
+				if len(chunk) != tt.wantChunks[i] {
+					t.Errorf("chunk %d: expected %d entries, got %d", i, tt.wantChunks[i], len(chunk))
+				}
+			}
+		})
+	}
+}
+
+func TestEventBridgePublisherPublishEvents(t *testing.T) {
+	order, err := domain.NewOrder("order-123", "customer-456", []domain.LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: domain.Money{Amount: 100, Currency: "EUR"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created := domain.NewOrderCreatedEvent("event-1", "corr-1", order)
+	confirmed, err := order.Confirm("event-2", "corr-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	requestFailed := func(*eventbridge.PutEventsInput) (*eventbridge.PutEventsOutput, error) {
+		return nil, errors.New("connection reset")
+	}
+
+	tests := []struct {
+		name          string
+		responses     []func(*eventbridge.PutEventsInput) (*eventbridge.PutEventsOutput, error)
+		wantCalls     int
+		wantRetried   int
+		wantFailed    int
+		wantRetriable bool
+	}{
+		{name: "all entries published", responses: []func(*eventbridge.PutEventsInput) (*eventbridge.PutEventsOutput, error){allEntriesSucceed}, wantCalls: 1},
+		{name: "throttled entry is retried alone", responses: []func(*eventbridge.PutEventsInput) (*eventbridge.PutEventsOutput, error){firstEntryFails("ThrottlingException"), allEntriesSucceed}, wantCalls: 2, wantRetried: 1},
+		{name: "rejected entry is not retried", responses: []func(*eventbridge.PutEventsInput) (*eventbridge.PutEventsOutput, error){firstEntryFails("MalformedDetail")}, wantCalls: 1, wantFailed: 1},
+		{name: "request failure exhausts retries", responses: []func(*eventbridge.PutEventsInput) (*eventbridge.PutEventsOutput, error){requestFailed, requestFailed, requestFailed}, wantCalls: 3, wantRetried: 2, wantFailed: 2, wantRetriable: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeEventBridge{responses: tt.responses}
+			publisher := NewEventBridgePublisher(client, "orders-bus", observability.NewLogger("", ""))
+			publisher.backoff = 0
+
+			results := publisher.PublishEvents(context.Background(), []*domain.Event{created, confirmed})
+
+			if len(client.inputs) != tt.wantCalls {
+				t.Fatalf("expected %d PutEvents calls, got %d", tt.wantCalls, len(client.inputs))
+			}
+			entries := client.inputs[0].Entries
+			if len(entries) != 2 || aws.ToString(entries[0].EventBusName) != "orders-bus" || aws.ToString(entries[0].DetailType) != domain.EventTypeOrderCreated || aws.ToString(entries[1].Source) != confirmed.Source {
+				t.Errorf("unexpected entries: %+v", entries)
+			}
+			if tt.wantRetried > 0 && len(client.inputs[1].Entries) != tt.wantRetried {
+				t.Errorf("expected %d retried entries, got %d", tt.wantRetried, len(client.inputs[1].Entries))
+			}
+
+			failed := 0
+			for _, result := range results {
+				if result.Err == nil {
+					continue
+				}
+				failed++
+				if domain.IsRetriable(result.Err) != tt.wantRetriable {
+					t.Errorf("expected retriable %v, got %v", tt.wantRetriable, result.Err)
+				}
+			}
+			if failed != tt.wantFailed {
+				t.Errorf("expected %d failed results, got %d", tt.wantFailed, failed)
+			}
+		})
+	}
+}
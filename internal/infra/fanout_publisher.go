@@ -0,0 +1,126 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+const (
+	PublishTargetEventBridge = "eventbridge"
+	PublishTargetSQS         = "sqs"
+	PublishTargetSNS         = "sns"
+	PublishTargetHTTP        = "http"
+	PublishTargetKafka       = "kafka"
+)
+
+type FailurePolicy string
+
+const (
+	// A failed required target fails the event, so the outbox keeps it and
+	// every target sees it again on the next relay run.
+	FailurePolicyRequired   FailurePolicy = "required"
+	FailurePolicyBestEffort FailurePolicy = "best-effort"
+)
+
+var ErrInvalidPublishTarget = errors.New("invalid publish target")
+
+type PublishTarget struct {
+	Name      string
+	Publisher EventPublisher
+	Policy    FailurePolicy
+}
+
+type PublishTargetSpec struct {
+	Kind   string
+	Policy FailurePolicy
+}
+
+func ParsePublishTargets(value string) ([]PublishTargetSpec, error) {
+	var specs []PublishTargetSpec
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		kind, policy, _ := strings.Cut(part, ":")
+		spec := PublishTargetSpec{Kind: strings.ToLower(kind), Policy: FailurePolicy(strings.ToLower(policy))}
+		if spec.Policy == "" {
+			spec.Policy = FailurePolicyRequired
+		}
+
+		switch spec.Kind {
+		case PublishTargetEventBridge, PublishTargetSQS, PublishTargetSNS, PublishTargetHTTP, PublishTargetKafka:
+		default:
+			return nil, fmt.Errorf("%w: unknown target %q", ErrInvalidPublishTarget, kind)
+		}
+		if spec.Policy != FailurePolicyRequired && spec.Policy != FailurePolicyBestEffort {
+			return nil, fmt.Errorf("%w: unknown failure policy %q for %s", ErrInvalidPublishTarget, policy, spec.Kind)
+		}
+		if seen[spec.Kind] {
+			return nil, fmt.Errorf("%w: duplicate target %q", ErrInvalidPublishTarget, spec.Kind)
+		}
+		seen[spec.Kind] = true
+
+		specs = append(specs, spec)
+	}
+
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("%w: no targets configured", ErrInvalidPublishTarget)
+	}
+	return specs, nil
+}
+
+type FanOutPublisher struct {
+	targets []PublishTarget
+	logger  *observability.Logger
+}
+
+func NewFanOutPublisher(targets []PublishTarget, logger *observability.Logger) *FanOutPublisher {
+	return &FanOutPublisher{
+		targets: targets,
+		logger:  logger,
+	}
+}
+
+func (p *FanOutPublisher) PublishEvent(ctx context.Context, event *domain.Event) error {
+	return firstResultError(p.PublishEvents(ctx, []*domain.Event{event}))
+}
+
+func (p *FanOutPublisher) PublishEvents(ctx context.Context, events []*domain.Event) []PublishResult {
+	targetResults := make([][]PublishResult, len(p.targets))
+	forEachConcurrently(len(p.targets), len(p.targets), func(t int) {
+		targetResults[t] = p.targets[t].Publisher.PublishEvents(ctx, events)
+	})
+
+	results := make([]PublishResult, len(events))
+	for i, event := range events {
+		results[i].Event = event
+		for t, target := range p.targets {
+			err := targetResults[t][i].Err
+			if err == nil {
+				continue
+			}
+			if target.Policy == FailurePolicyBestEffort {
+				p.logger.Warn("best-effort publish target failed", map[string]interface{}{
+					"target":   target.Name,
+					"event_id": event.EventID,
+					"error":    err.Error(),
+				})
+				continue
+			}
+			if results[i].Err == nil {
+				results[i].Err = fmt.Errorf("target %s: %w", target.Name, err)
+			}
+		}
+	}
+
+	return results
+}
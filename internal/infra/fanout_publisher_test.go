@@ -0,0 +1,239 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+type stubPublisher struct {
+	err    error
+	events []*domain.Event
+}
+
+func (s *stubPublisher) PublishEvent(ctx context.Context, event *domain.Event) error {
+	return firstResultError(s.PublishEvents(ctx, []*domain.Event{event}))
+}
+
+func (s *stubPublisher) PublishEvents(ctx context.Context, events []*domain.Event) []PublishResult {
+	s.events = append(s.events, events...)
+	results := make([]PublishResult, len(events))
+	for i, event := range events {
+		results[i] = PublishResult{Event: event, Err: s.err}
+	}
+	return results
+}
+
+type fakeSQS struct {
+	output *sqs.SendMessageBatchOutput
+}
+
+func (f *fakeSQS) SendMessageBatch(ctx context.Context, params *sqs.SendMessageBatchInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageBatchOutput, error) {
+	return f.output, nil
+}
+
+type fakeSNS struct {
+	output *sns.PublishBatchOutput
+}
+
+func (f *fakeSNS) PublishBatch(ctx context.Context, params *sns.PublishBatchInput, optFns ...func(*sns.Options)) (*sns.PublishBatchOutput, error) {
+	return f.output, nil
+}
+
+func TestBatchPublishersFailedEntryIDs(t *testing.T) {
+	tests := []struct {
+		name         string
+		id           string
+		wantBatchErr bool
+	}{
+		{name: "known id fails only its entry", id: "1"},
+		{name: "unparsable id fails the batch", id: "msg-1", wantBatchErr: true},
+		{name: "out of range id fails the batch", id: "5", wantBatchErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			logger := observability.NewLogger("", "")
+
+			sqsPublisher := NewSQSPublisher(&fakeSQS{output: &sqs.SendMessageBatchOutput{
+				Failed: []sqstypes.BatchResultErrorEntry{{Id: aws.String(tt.id), Code: aws.String("InternalError")}},
+			}}, "https://sqs.example/queue", domain.EventFormatEventBridge, logger)
+			snsPublisher := NewSNSPublisher(&fakeSNS{output: &sns.PublishBatchOutput{
+				Failed: []snstypes.BatchResultErrorEntry{{Id: aws.String(tt.id), Code: aws.String("InternalError")}},
+			}}, "arn:aws:sns:eu-central-1:123456789012:events", domain.EventFormatEventBridge, logger)
+
+			for target, errs := range map[string][]error{
+				"sqs": sqsPublisher.sendBatch(ctx, make([]sqstypes.SendMessageBatchRequestEntry, 2)),
+				"sns": snsPublisher.publishBatch(ctx, make([]snstypes.PublishBatchRequestEntry, 2)),
+			} {
+				if !domain.IsRetriable(errs[1]) {
+					t.Errorf("%s: expected the second entry to fail retriably, got %v", target, errs[1])
+				}
+				if (errs[0] != nil) != tt.wantBatchErr {
+					t.Errorf("%s: expected the first entry to fail %v, got %v", target, tt.wantBatchErr, errs[0])
+				}
+				if tt.wantBatchErr && !domain.IsRetriable(errs[0]) {
+					t.Errorf("%s: expected a retriable batch error, got %v", target, errs[0])
+				}
+			}
+		})
+	}
+}
+
+func TestParsePublishTargets(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []PublishTargetSpec
+		wantErr bool
+	}{
+		{name: "single target defaults to required", value: "eventbridge", want: []PublishTargetSpec{{Kind: PublishTargetEventBridge, Policy: FailurePolicyRequired}}},
+		{name: "policies per target", value: "eventbridge, SQS:best-effort,http:required", want: []PublishTargetSpec{
+			{Kind: PublishTargetEventBridge, Policy: FailurePolicyRequired},
+			{Kind: PublishTargetSQS, Policy: FailurePolicyBestEffort},
+			{Kind: PublishTargetHTTP, Policy: FailurePolicyRequired},
+		}},
+		{name: "unknown target", value: "kinesis", wantErr: true},
+		{name: "unknown policy", value: "sns:sometimes", wantErr: true},
+		{name: "duplicate target", value: "sqs,sqs:best-effort", wantErr: true},
+		{name: "empty", value: " , ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specs, err := ParsePublishTargets(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if len(specs) != len(tt.want) {
+				t.Fatalf("expected %d targets, got %d", len(tt.want), len(specs))
+			}
+			for i := range specs {
+				if specs[i] != tt.want[i] {
+					t.Errorf("target %d: expected %+v, got %+v", i, tt.want[i], specs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFanOutPublisher(t *testing.T) {
+	rejected := domain.NewNonRetriableError(errors.New("MalformedDetail"), "rejected")
+	events := []*domain.Event{{EventID: "event-1"}, {EventID: "event-2"}}
+
+	tests := []struct {
+		name          string
+		targets       []PublishTarget
+		wantErr       bool
+		wantRetriable bool
+	}{
+		{
+			name: "all targets succeed",
+			targets: []PublishTarget{
+				{Name: "eventbridge", Publisher: &stubPublisher{}, Policy: FailurePolicyRequired},
+				{Name: "sqs", Publisher: &stubPublisher{}, Policy: FailurePolicyRequired},
+			},
+		},
+		{
+			name: "best-effort failure is ignored",
+			targets: []PublishTarget{
+				{Name: "eventbridge", Publisher: &stubPublisher{}, Policy: FailurePolicyRequired},
+				{Name: "http", Publisher: &stubPublisher{err: rejected}, Policy: FailurePolicyBestEffort},
+			},
+		},
+		{
+			name: "required failure keeps its classification",
+			targets: []PublishTarget{
+				{Name: "eventbridge", Publisher: &stubPublisher{}, Policy: FailurePolicyRequired},
+				{Name: "sns", Publisher: &stubPublisher{err: domain.NewRetriableError(errors.New("Throttled"), "failed")}, Policy: FailurePolicyRequired},
+			},
+			wantErr:       true,
+			wantRetriable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := NewFanOutPublisher(tt.targets, observability.NewLogger("", ""))
+			results := publisher.PublishEvents(context.Background(), events)
+
+			for i, result := range results {
+				if result.Event != events[i] {
+					t.Errorf("result %d does not belong to event %s", i, events[i].EventID)
+				}
+				if (result.Err != nil) != tt.wantErr {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, result.Err)
+				}
+				if result.Err != nil && domain.IsRetriable(result.Err) != tt.wantRetriable {
+					t.Errorf("expected retriable %v, got %v", tt.wantRetriable, domain.IsRetriable(result.Err))
+				}
+			}
+			for _, target := range tt.targets {
+				if got := len(target.Publisher.(*stubPublisher).events); got != len(events) {
+					t.Errorf("target %s: expected %d events, got %d", target.Name, len(events), got)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPPublisher(t *testing.T) {
+	order, err := domain.NewOrder("order-123", "customer-456", []domain.LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: domain.Money{Amount: 100, Currency: "EUR"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	event := domain.NewOrderCreatedEvent("event-1", "corr-1", order)
+
+	tests := []struct {
+		name          string
+		statuses      []int
+		wantCalls     int
+		wantErr       bool
+		wantRetriable bool
+	}{
+		{name: "accepted", statuses: []int{http.StatusAccepted}, wantCalls: 1},
+		{name: "retries server errors", statuses: []int{http.StatusServiceUnavailable, http.StatusOK}, wantCalls: 2},
+		{name: "gives up after max attempts", statuses: []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests}, wantCalls: 3, wantErr: true, wantRetriable: true},
+		{name: "client errors are not retried", statuses: []int{http.StatusBadRequest}, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Content-Type") != "application/cloudevents+json" {
+					t.Errorf("unexpected content type %q", r.Header.Get("Content-Type"))
+				}
+				w.WriteHeader(tt.statuses[calls])
+				calls++
+			}))
+			defer server.Close()
+
+			publisher := NewHTTPPublisher(server.URL, domain.EventFormatCloudEvents, observability.NewLogger("", ""))
+			publisher.backoff = time.Millisecond
+
+			err := publisher.PublishEvent(context.Background(), event)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && domain.IsRetriable(err) != tt.wantRetriable {
+				t.Errorf("expected retriable %v, got %v", tt.wantRetriable, domain.IsRetriable(err))
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// FileCheckpointStore keeps a replay checkpoint in a local file. Saving a
+// checkpoint without a cursor removes the file.
+type FileCheckpointStore struct {
+	path string
+}
+
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+func (s *FileCheckpointStore) LoadCheckpoint(ctx context.Context) (*ReplayCheckpoint, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoint: %w", err)
+	}
+	var checkpoint ReplayCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil || checkpoint.RunID == "" {
+		return nil, fmt.Errorf("invalid checkpoint in %s", s.path)
+	}
+	return &checkpoint, nil
+}
+
+func (s *FileCheckpointStore) SaveCheckpoint(ctx context.Context, checkpoint *ReplayCheckpoint) error {
+	if checkpoint == nil || checkpoint.Cursor == "" {
+		if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove checkpoint: %w", err)
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("marshal checkpoint: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+const defaultHTTPPublisherTimeout = 5 * time.Second
+
+type HTTPPublisher struct {
+	client      *http.Client
+	endpoint    string
+	eventTypes  *domain.EventTypeRegistry
+	format      domain.EventFormat
+	logger      *observability.Logger
+	maxAttempts int
+	backoff     time.Duration
+	concurrency int
+}
+
+func NewHTTPPublisher(endpoint string, format domain.EventFormat, logger *observability.Logger) *HTTPPublisher {
+	return NewHTTPPublisherWithClient(&http.Client{Timeout: defaultHTTPPublisherTimeout}, endpoint, format, logger)
+}
+
+func NewHTTPPublisherWithClient(client *http.Client, endpoint string, format domain.EventFormat, logger *observability.Logger) *HTTPPublisher {
+	return &HTTPPublisher{
+		client:      client,
+		endpoint:    endpoint,
+		eventTypes:  domain.DefaultEventTypes(),
+		format:      format,
+		logger:      logger,
+		maxAttempts: defaultPublishMaxAttempts,
+		backoff:     defaultPublishBackoff,
+		concurrency: defaultPublishConcurrency,
+	}
+}
+
+func (p *HTTPPublisher) PublishEvent(ctx context.Context, event *domain.Event) error {
+	return firstResultError(p.PublishEvents(ctx, []*domain.Event{event}))
+}
+
+func (p *HTTPPublisher) PublishEvents(ctx context.Context, events []*domain.Event) []PublishResult {
+	results := publishConcurrently(ctx, events, p.concurrency, func(ctx context.Context, event *domain.Event) error {
+		body, err := encodeForPublish(p.eventTypes, p.format, event, p.logger)
+		if err != nil {
+			return err
+		}
+		return sendWithRetry(ctx, 1, p.maxAttempts, p.backoff, p.logger, func(ctx context.Context, _ []int) []error {
+			return []error{p.post(ctx, event, body)}
+		})[0]
+	})
+
+	logPublishResults(p.logger, "http", results)
+
+	return results
+}
+
+func (p *HTTPPublisher) post(ctx context.Context, event *domain.Event, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return domain.NewNonRetriableError(err, "failed to build http request")
+	}
+
+	if p.format == domain.EventFormatCloudEvents {
+		req.Header.Set("Content-Type", "application/cloudevents+json")
+	} else {
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Event-Id", event.EventID)
+		req.Header.Set("X-Event-Type", event.EventType)
+		req.Header.Set("X-Event-Source", event.Source)
+		req.Header.Set("X-Event-Version", event.Version)
+		if event.CorrelationID != "" {
+			req.Header.Set("X-Correlation-Id", event.CorrelationID)
+		}
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return domain.NewRetriableError(err, "http publish request failed")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	return classifyHTTPStatus(resp.StatusCode)
+}
+
+func classifyHTTPStatus(statusCode int) error {
+	switch {
+	case statusCode >= 200 && statusCode < 300:
+		return nil
+	case statusCode == http.StatusRequestTimeout, statusCode == http.StatusTooManyRequests, statusCode >= 500:
+		return domain.NewRetriableError(fmt.Errorf("http target responded with %d", statusCode), "http publish failed")
+	default:
+		return domain.NewNonRetriableError(fmt.Errorf("http target responded with %d", statusCode), "http publish rejected")
+	}
+}
@@ -0,0 +1,172 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+const (
+	IdempotencyStatusInProgress = "IN_PROGRESS"
+	IdempotencyStatusCompleted  = "COMPLETED"
+)
+
+type DynamoDBIdempotencyRepository struct {
+	client      DynamoDBIdempotencyAPI
+	tableName   string
+	logger      *observability.Logger
+	ttlHours    int
+	lockTimeout time.Duration
+}
+
+func NewDynamoDBIdempotencyRepository(client DynamoDBIdempotencyAPI, tableName string, logger *observability.Logger) *DynamoDBIdempotencyRepository {
+	return &DynamoDBIdempotencyRepository{
+		client:      client,
+		tableName:   tableName,
+		logger:      logger,
+		ttlHours:    24,
+		lockTimeout: 30 * time.Second,
+	}
+}
+
+func NewDynamoDBIdempotencyRepositoryWithTTL(client DynamoDBIdempotencyAPI, tableName string, logger *observability.Logger, ttlHours int) *DynamoDBIdempotencyRepository {
+	return &DynamoDBIdempotencyRepository{
+		client:      client,
+		tableName:   tableName,
+		logger:      logger,
+		ttlHours:    ttlHours,
+		lockTimeout: 30 * time.Second,
+	}
+}
+
+type IdempotencyItem struct {
+	IdempotencyKey string `dynamodbav:"idempotency_key"`
+	RequestHash    string `dynamodbav:"request_hash"`
+	Status         string `dynamodbav:"status"`
+	StatusCode     int    `dynamodbav:"status_code,omitempty"`
+	ResponseBody   string `dynamodbav:"response_body,omitempty"`
+	LockedUntil    int64  `dynamodbav:"locked_until,omitempty"`
+	CreatedAt      string `dynamodbav:"created_at"`
+	TTL            int64  `dynamodbav:"ttl,omitempty"`
+}
+
+func (r *DynamoDBIdempotencyRepository) Acquire(ctx context.Context, key, requestHash string) (*IdempotencyRecord, error) {
+	now := time.Now().UTC()
+
+	item := IdempotencyItem{
+		IdempotencyKey: key,
+		RequestHash:    requestHash,
+		Status:         IdempotencyStatusInProgress,
+		LockedUntil:    now.Add(r.lockTimeout).Unix(),
+		CreatedAt:      now.Format(time.RFC3339),
+		TTL:            now.Add(time.Duration(r.ttlHours) * time.Hour).Unix(),
+	}
+
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		r.logger.Error("failed to marshal idempotency record", err)
+		return nil, fmt.Errorf("marshal idempotency record: %w", err)
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(r.tableName),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(idempotency_key) OR (#status = :in_progress AND locked_until < :now)"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":in_progress": &types.AttributeValueMemberS{Value: IdempotencyStatusInProgress},
+			":now":         &types.AttributeValueMemberN{Value: strconv.FormatInt(now.Unix(), 10)},
+		},
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	})
+
+	if err != nil {
+		var condCheckErr *types.ConditionalCheckFailedException
+		if errors.As(err, &condCheckErr) {
+			var existing IdempotencyItem
+			if err := attributevalue.UnmarshalMap(condCheckErr.Item, &existing); err != nil {
+				r.logger.Error("failed to unmarshal idempotency record", err)
+				return nil, fmt.Errorf("unmarshal idempotency record: %w", err)
+			}
+			return &IdempotencyRecord{
+				Key:          existing.IdempotencyKey,
+				RequestHash:  existing.RequestHash,
+				Status:       existing.Status,
+				StatusCode:   existing.StatusCode,
+				ResponseBody: existing.ResponseBody,
+			}, nil
+		}
+		r.logger.Error("failed to acquire idempotency key", err, map[string]interface{}{
+			"idempotency_key": key,
+		})
+		return nil, fmt.Errorf("acquire idempotency key: %w", err)
+	}
+
+	return nil, nil
+}
+
+func (r *DynamoDBIdempotencyRepository) Complete(ctx context.Context, key string, statusCode int, responseBody string) error {
+	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"idempotency_key": &types.AttributeValueMemberS{Value: key},
+		},
+		UpdateExpression: aws.String("SET #status = :completed, status_code = :status_code, response_body = :response_body REMOVE locked_until"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":completed":     &types.AttributeValueMemberS{Value: IdempotencyStatusCompleted},
+			":status_code":   &types.AttributeValueMemberN{Value: strconv.Itoa(statusCode)},
+			":response_body": &types.AttributeValueMemberS{Value: responseBody},
+		},
+	})
+
+	if err != nil {
+		r.logger.Error("failed to store idempotent response", err, map[string]interface{}{
+			"idempotency_key": key,
+		})
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DynamoDBIdempotencyRepository) Release(ctx context.Context, key string) error {
+	_, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"idempotency_key": &types.AttributeValueMemberS{Value: key},
+		},
+		ConditionExpression: aws.String("#status = :in_progress"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":in_progress": &types.AttributeValueMemberS{Value: IdempotencyStatusInProgress},
+		},
+	})
+
+	if err != nil {
+		var condCheckErr *types.ConditionalCheckFailedException
+		if errors.As(err, &condCheckErr) {
+			return nil
+		}
+		r.logger.Error("failed to release idempotency key", err, map[string]interface{}{
+			"idempotency_key": key,
+		})
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+
+	return nil
+}
@@ -2,26 +2,30 @@ package infra
 
 import (
 	"context"
+	"errors"
 	"testing"
 
-	"github.com/stevenbode/go-serverless-event-platform/internal/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
 )
 
 type MockEventRepository struct {
-	events map[string]bool
+	versions map[domain.OrderID]int64
 }
 
 func NewMockEventRepository() *MockEventRepository {
 	return &MockEventRepository{
-		events: make(map[string]bool),
+		versions: make(map[domain.OrderID]int64),
 	}
 }
 
-func (m *MockEventRepository) SaveEvent(ctx context.Context, event *domain.Event) error {
-	if m.events[event.EventID] {
+func (m *MockEventRepository) SaveEvent(ctx context.Context, event *domain.Event, expectedVersion int64) error {
+	if expectedVersion == 0 && m.versions[event.OrderID] > 0 {
 		return domain.ErrOrderAlreadyExists
 	}
-	m.events[event.EventID] = true
+	if m.versions[event.OrderID] != expectedVersion {
+		return &domain.ConcurrencyError{OrderID: event.OrderID, ExpectedVersion: expectedVersion}
+	}
+	m.versions[event.OrderID] = event.AggregateVersion
 	return nil
 }
 
@@ -29,6 +33,10 @@ func (m *MockEventRepository) GetEventsByOrderID(ctx context.Context, orderID do
 	return nil, nil
 }
 
+func (m *MockEventRepository) GetEventsAfterVersion(ctx context.Context, orderID domain.OrderID, afterVersion int64) ([]*domain.Event, error) {
+	return nil, nil
+}
+
 type MockProcessedEventsRepository struct {
 	processed map[string]bool
 }
@@ -56,24 +64,42 @@ func TestIdempotency_EventRepository(t *testing.T) {
 	ctx := context.Background()
 
 	event := &domain.Event{
-		EventID:       "event-123",
-		CorrelationID: "corr-123",
-		EventType:     domain.EventTypeOrderCreated,
-		Source:        domain.EventSourceOrders,
-		OrderID:       "order-123",
-		CustomerID:    "customer-456",
-		TotalCents:    10000,
+		EventID:          "event-123",
+		CorrelationID:    "corr-123",
+		EventType:        domain.EventTypeOrderCreated,
+		Source:           domain.EventSourceOrders,
+		AggregateVersion: 1,
+		OrderID:          "order-123",
+		CustomerID:       "customer-456",
+		TotalCents:       10000,
 	}
 
-	err := repo.SaveEvent(ctx, event)
+	err := repo.SaveEvent(ctx, event, 0)
 	if err != nil {
 		t.Errorf("unexpected error on first save: %v", err)
 	}
 
-	err = repo.SaveEvent(ctx, event)
+	err = repo.SaveEvent(ctx, event, 0)
 	if err != domain.ErrOrderAlreadyExists {
 		t.Errorf("expected ErrOrderAlreadyExists on duplicate save, got: %v", err)
 	}
+
+	next := *event
+	next.EventID = "event-124"
+	next.AggregateVersion = 2
+
+	err = repo.SaveEvent(ctx, &next, 0)
+	if err != domain.ErrOrderAlreadyExists {
+		t.Errorf("expected ErrOrderAlreadyExists when appending with expected version 0, got: %v", err)
+	}
+
+	stale := next
+	stale.AggregateVersion = 3
+
+	err = repo.SaveEvent(ctx, &stale, 2)
+	if !errors.Is(err, domain.ErrConcurrencyConflict) {
+		t.Errorf("expected ErrConcurrencyConflict on stale expected version, got: %v", err)
+	}
 }
 
 func TestIdempotency_ProcessedEventsRepository(t *testing.T) {
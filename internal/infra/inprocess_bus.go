@@ -0,0 +1,72 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+// InProcessEventBus delivers every event synchronously to its subscribers.
+// Events go through the same encoding and schema validation as the real
+// publishers, so handlers see exactly what a consumer would receive.
+type InProcessEventBus struct {
+	mu          sync.RWMutex
+	subscribers []EventHandler
+	eventTypes  *domain.EventTypeRegistry
+	format      domain.EventFormat
+	logger      *observability.Logger
+}
+
+func NewInProcessEventBus(logger *observability.Logger) *InProcessEventBus {
+	return &InProcessEventBus{
+		eventTypes: domain.DefaultEventTypes(),
+		format:     domain.EventFormatEventBridge,
+		logger:     logger,
+	}
+}
+
+func (b *InProcessEventBus) Subscribe(handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, handler)
+}
+
+func (b *InProcessEventBus) PublishEvent(ctx context.Context, event *domain.Event) error {
+	return firstResultError(b.PublishEvents(ctx, []*domain.Event{event}))
+}
+
+func (b *InProcessEventBus) PublishEvents(ctx context.Context, events []*domain.Event) []PublishResult {
+	b.mu.RLock()
+	subscribers := append([]EventHandler(nil), b.subscribers...)
+	b.mu.RUnlock()
+
+	results := make([]PublishResult, len(events))
+	for i, event := range events {
+		results[i] = PublishResult{Event: event, Err: b.deliver(ctx, subscribers, event)}
+	}
+
+	logPublishResults(b.logger, "in-process", results)
+
+	return results
+}
+
+func (b *InProcessEventBus) deliver(ctx context.Context, subscribers []EventHandler, event *domain.Event) error {
+	encoded, err := encodeForPublish(b.eventTypes, b.format, event, b.logger)
+	if err != nil {
+		return err
+	}
+	received, err := domain.DecodeEventDetail(event.Source, event.EventType, encoded)
+	if err != nil {
+		return domain.NewNonRetriableError(err, "failed to decode event")
+	}
+
+	for _, handle := range subscribers {
+		if err := handle(ctx, received); err != nil {
+			return fmt.Errorf("deliver event %s: %w", event.EventID, err)
+		}
+	}
+	return nil
+}
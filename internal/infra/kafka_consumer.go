@@ -0,0 +1,129 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+const (
+	defaultConsumerBackoff    = 200 * time.Millisecond
+	defaultConsumerMaxBackoff = 30 * time.Second
+)
+
+type KafkaMessageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+func NewKafkaReader(brokers []string, topic, groupID string) *kafka.Reader {
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers: brokers,
+		Topic:   topic,
+		GroupID: groupID,
+	})
+}
+
+type EventHandler func(ctx context.Context, event *domain.Event) error
+
+type KafkaConsumer struct {
+	reader     KafkaMessageReader
+	handle     EventHandler
+	logger     *observability.Logger
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+func NewKafkaConsumer(reader KafkaMessageReader, handle EventHandler, logger *observability.Logger) *KafkaConsumer {
+	return &KafkaConsumer{
+		reader:     reader,
+		handle:     handle,
+		logger:     logger,
+		backoff:    defaultConsumerBackoff,
+		maxBackoff: defaultConsumerMaxBackoff,
+	}
+}
+
+// Run consumes until ctx is cancelled. Offsets are committed only after a
+// message was applied or classified as non-retriable, so ordering per order_id
+// key is preserved.
+func (c *KafkaConsumer) Run(ctx context.Context) error {
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			c.logger.Error("failed to fetch kafka message", err)
+			return fmt.Errorf("fetch message: %w", err)
+		}
+
+		if err := c.process(ctx, msg); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			c.logger.Error("failed to commit kafka message", err, map[string]interface{}{
+				"partition": msg.Partition,
+				"offset":    msg.Offset,
+			})
+			return fmt.Errorf("commit message: %w", err)
+		}
+	}
+}
+
+func (c *KafkaConsumer) process(ctx context.Context, msg kafka.Message) error {
+	event, err := EventFromKafkaMessage(msg)
+	if err != nil {
+		c.logger.Error("failed to decode kafka message", err, map[string]interface{}{
+			"partition": msg.Partition,
+			"offset":    msg.Offset,
+		})
+		return nil
+	}
+
+	backoff := c.backoff
+	for {
+		err := c.handle(ctx, event)
+		if err == nil {
+			return nil
+		}
+		if !domain.IsRetriable(err) {
+			c.logger.Error("skipping kafka message", err, map[string]interface{}{
+				"event_id":   event.EventID,
+				"event_type": event.EventType,
+				"offset":     msg.Offset,
+			})
+			return nil
+		}
+
+		c.logger.Warn("retrying kafka message", map[string]interface{}{
+			"event_id": event.EventID,
+			"offset":   msg.Offset,
+			"error":    err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > c.maxBackoff {
+			backoff = c.maxBackoff
+		}
+	}
+}
+
+func EventFromKafkaMessage(msg kafka.Message) (*domain.Event, error) {
+	return domain.DecodeEventDetail(kafkaHeader(msg, kafkaHeaderSource), kafkaHeader(msg, kafkaHeaderEventType), msg.Value)
+}
@@ -0,0 +1,97 @@
+package infra
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// InProcessKafkaTopic stands in for a single-partition topic with one consumer
+// group. It satisfies KafkaMessageWriter and KafkaMessageReader.
+type InProcessKafkaTopic struct {
+	mu        sync.Mutex
+	topic     string
+	messages  []kafka.Message
+	next      int64
+	committed int64
+	notify    chan struct{}
+}
+
+func NewInProcessKafkaTopic(topic string) *InProcessKafkaTopic {
+	return &InProcessKafkaTopic{
+		topic:  topic,
+		notify: make(chan struct{}),
+	}
+}
+
+func (t *InProcessKafkaTopic) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, msg := range msgs {
+		msg.Topic = t.topic
+		msg.Offset = int64(len(t.messages))
+		if msg.Time.IsZero() {
+			msg.Time = time.Now().UTC()
+		}
+		t.messages = append(t.messages, msg)
+	}
+
+	close(t.notify)
+	t.notify = make(chan struct{})
+	return nil
+}
+
+func (t *InProcessKafkaTopic) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	for {
+		t.mu.Lock()
+		if t.next < int64(len(t.messages)) {
+			msg := t.messages[t.next]
+			t.next++
+			t.mu.Unlock()
+			return msg, nil
+		}
+		notify := t.notify
+		t.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return kafka.Message{}, ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+func (t *InProcessKafkaTopic) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, msg := range msgs {
+		if msg.Offset+1 > t.committed {
+			t.committed = msg.Offset + 1
+		}
+	}
+	return nil
+}
+
+// Rewind simulates a consumer restart: delivery resumes after the last
+// committed offset.
+func (t *InProcessKafkaTopic) Rewind() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.next = t.committed
+}
+
+func (t *InProcessKafkaTopic) Committed() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.committed
+}
+
+func (t *InProcessKafkaTopic) Messages() []kafka.Message {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]kafka.Message(nil), t.messages...)
+}
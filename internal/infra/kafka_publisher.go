@@ -0,0 +1,153 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+const (
+	kafkaHeaderEventID       = "event_id"
+	kafkaHeaderEventType     = "event_type"
+	kafkaHeaderSource        = "source"
+	kafkaHeaderVersion       = "version"
+	kafkaHeaderCorrelationID = "correlation_id"
+)
+
+type KafkaMessageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+func NewKafkaWriter(brokers []string, topic string) *kafka.Writer {
+	return &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		BatchTimeout: 10 * time.Millisecond,
+	}
+}
+
+type KafkaPublisher struct {
+	writer      KafkaMessageWriter
+	eventTypes  *domain.EventTypeRegistry
+	format      domain.EventFormat
+	logger      *observability.Logger
+	maxAttempts int
+	backoff     time.Duration
+}
+
+func NewKafkaPublisher(writer KafkaMessageWriter, format domain.EventFormat, logger *observability.Logger) *KafkaPublisher {
+	return &KafkaPublisher{
+		writer:      writer,
+		eventTypes:  domain.DefaultEventTypes(),
+		format:      format,
+		logger:      logger,
+		maxAttempts: defaultPublishMaxAttempts,
+		backoff:     defaultPublishBackoff,
+	}
+}
+
+func (p *KafkaPublisher) PublishEvent(ctx context.Context, event *domain.Event) error {
+	return firstResultError(p.PublishEvents(ctx, []*domain.Event{event}))
+}
+
+func (p *KafkaPublisher) PublishEvents(ctx context.Context, events []*domain.Event) []PublishResult {
+	results := make([]PublishResult, len(events))
+	messages := make([]kafka.Message, 0, len(events))
+	positions := make([]int, 0, len(events))
+
+	for i, event := range events {
+		results[i].Event = event
+		value, err := encodeForPublish(p.eventTypes, p.format, event, p.logger)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		messages = append(messages, kafka.Message{
+			Key:     []byte(event.OrderID),
+			Value:   value,
+			Headers: kafkaHeaders(event),
+		})
+		positions = append(positions, i)
+	}
+
+	if len(messages) > 0 {
+		errs := sendWithRetry(ctx, len(messages), p.maxAttempts, p.backoff, p.logger, func(ctx context.Context, pending []int) []error {
+			batch := make([]kafka.Message, len(pending))
+			for i, idx := range pending {
+				batch[i] = messages[idx]
+			}
+			return kafkaWriteErrors(p.writer.WriteMessages(ctx, batch...), len(batch))
+		})
+		for i, err := range errs {
+			results[positions[i]].Err = err
+		}
+	}
+
+	logPublishResults(p.logger, "kafka", results)
+
+	return results
+}
+
+func kafkaHeaders(event *domain.Event) []kafka.Header {
+	headers := []kafka.Header{
+		{Key: kafkaHeaderEventID, Value: []byte(event.EventID)},
+		{Key: kafkaHeaderEventType, Value: []byte(event.EventType)},
+		{Key: kafkaHeaderSource, Value: []byte(event.Source)},
+		{Key: kafkaHeaderVersion, Value: []byte(event.Version)},
+	}
+	if event.CorrelationID != "" {
+		headers = append(headers, kafka.Header{Key: kafkaHeaderCorrelationID, Value: []byte(event.CorrelationID)})
+	}
+	return headers
+}
+
+func kafkaHeader(msg kafka.Message, key string) string {
+	for _, header := range msg.Headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func kafkaWriteErrors(err error, size int) []error {
+	errs := make([]error, size)
+	if err == nil {
+		return errs
+	}
+
+	var writeErrs kafka.WriteErrors
+	if errors.As(err, &writeErrs) && len(writeErrs) == size {
+		for i, writeErr := range writeErrs {
+			if writeErr != nil {
+				errs[i] = classifyKafkaError(writeErr)
+			}
+		}
+		return errs
+	}
+
+	classified := classifyKafkaError(err)
+	for i := range errs {
+		errs[i] = classified
+	}
+	return errs
+}
+
+func classifyKafkaError(err error) error {
+	var tooLarge kafka.MessageTooLargeError
+	if errors.As(err, &tooLarge) || errors.Is(err, kafka.MessageSizeTooLarge) {
+		return domain.NewNonRetriableError(err, "kafka message rejected")
+	}
+
+	var kafkaErr kafka.Error
+	if errors.As(err, &kafkaErr) && !kafkaErr.Temporary() {
+		return domain.NewNonRetriableError(err, "kafka message rejected")
+	}
+	return domain.NewRetriableError(err, "kafka write failed")
+}
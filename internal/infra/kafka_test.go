@@ -0,0 +1,126 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+func TestKafkaPublisherAndConsumer(t *testing.T) {
+	for _, format := range []domain.EventFormat{domain.EventFormatEventBridge, domain.EventFormatCloudEvents} {
+		t.Run(string(format), func(t *testing.T) {
+			logger := observability.NewLogger("", "")
+			topic := NewInProcessKafkaTopic("orders")
+
+			order, err := domain.NewOrder("order-123", "customer-456", []domain.LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: domain.Money{Amount: 100, Currency: "EUR"}}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			created := domain.NewOrderCreatedEvent("event-1", "corr-1", order)
+			confirmed, err := order.Confirm("event-2", "corr-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			publisher := NewKafkaPublisher(topic, format, logger)
+			for _, result := range publisher.PublishEvents(context.Background(), []*domain.Event{created, confirmed}) {
+				if result.Err != nil {
+					t.Fatalf("unexpected error: %v", result.Err)
+				}
+			}
+			for _, msg := range topic.Messages() {
+				if string(msg.Key) != "order-123" {
+					t.Errorf("expected message key order-123, got %s", msg.Key)
+				}
+			}
+
+			var mu sync.Mutex
+			var handled []string
+			failures := 1
+			ctx, cancel := context.WithCancel(context.Background())
+			consumer := NewKafkaConsumer(topic, func(ctx context.Context, event *domain.Event) error {
+				mu.Lock()
+				defer mu.Unlock()
+				if event.EventType == domain.EventTypeOrderConfirmed && failures > 0 {
+					failures--
+					return domain.NewRetriableError(errors.New("read model not ready"), "retry")
+				}
+				handled = append(handled, event.EventID)
+				if len(handled) == 2 {
+					cancel()
+				}
+				return nil
+			}, logger)
+			consumer.backoff = time.Millisecond
+
+			done := make(chan error, 1)
+			go func() { done <- consumer.Run(ctx) }()
+
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			case <-time.After(5 * time.Second):
+				cancel()
+				t.Fatal("consumer did not finish")
+			}
+
+			if len(handled) != 2 || handled[0] != "event-1" || handled[1] != "event-2" {
+				t.Errorf("expected events in order, got %v", handled)
+			}
+			if topic.Committed() != 2 {
+				t.Errorf("expected both offsets to be committed, got %d", topic.Committed())
+			}
+		})
+	}
+}
+
+func TestKafkaConsumerIgnoresBareProcessedMarkers(t *testing.T) {
+	logger := observability.NewLogger("", "")
+	client := newFakeDynamoDB()
+	client.items["event-1"] = map[string]types.AttributeValue{"event_id": &types.AttributeValueMemberS{Value: "event-1"}}
+	processedRepo := NewDynamoDBProcessedEventsRepository(client, "processed", "orders-read-model", logger)
+
+	order, err := domain.NewOrder("order-123", "customer-456", []domain.LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: domain.Money{Amount: 100, Currency: "EUR"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	topic := NewInProcessKafkaTopic("orders")
+	publisher := NewKafkaPublisher(topic, domain.EventFormatEventBridge, logger)
+	if err := publisher.PublishEvent(context.Background(), domain.NewOrderCreatedEvent("event-1", "corr-1", order)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var skipped, handled int
+	ctx, cancel := context.WithCancel(context.Background())
+	consumer := NewKafkaConsumer(topic, func(ctx context.Context, event *domain.Event) error {
+		defer cancel()
+		processed, err := processedRepo.IsProcessed(ctx, event.EventID)
+		if err != nil {
+			return err
+		}
+		if processed {
+			skipped++
+			return nil
+		}
+		handled++
+		return processedRepo.MarkAsProcessed(ctx, event.EventID)
+	}, logger)
+
+	if err := consumer.Run(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handled != 1 || skipped != 0 {
+		t.Errorf("expected the event to be handled despite the bare marker, handled %d skipped %d", handled, skipped)
+	}
+	if _, ok := client.items["orders-read-model#event-1"]; !ok {
+		t.Errorf("expected a marker scoped to the kafka consumer")
+	}
+}
@@ -0,0 +1,202 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+)
+
+// InMemoryEventRepository keeps the event streams and the outbox in one
+// structure so SaveEvent stays atomic, like the DynamoDB transaction.
+type InMemoryEventRepository struct {
+	mu      sync.Mutex
+	streams map[domain.OrderID][]*domain.Event
+	outbox  []*memoryOutboxEntry
+	nextSeq int64
+}
+
+type memoryOutboxEntry struct {
+	OutboxEntry
+	seq    int64
+	failed bool
+}
+
+func NewInMemoryEventRepository() *InMemoryEventRepository {
+	return &InMemoryEventRepository{
+		streams: make(map[domain.OrderID][]*domain.Event),
+	}
+}
+
+func (r *InMemoryEventRepository) SaveEvent(ctx context.Context, event *domain.Event, expectedVersion int64) error {
+	if event.AggregateVersion != expectedVersion+1 {
+		return fmt.Errorf("event version %d does not follow expected version %d", event.AggregateVersion, expectedVersion)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stream := r.streams[event.OrderID]
+	if int64(len(stream)) != expectedVersion {
+		if expectedVersion == 0 {
+			return domain.ErrOrderAlreadyExists
+		}
+		return &domain.ConcurrencyError{OrderID: event.OrderID, ExpectedVersion: expectedVersion}
+	}
+
+	stored := copyEvent(event)
+	r.streams[event.OrderID] = append(stream, stored)
+	r.nextSeq++
+	r.outbox = append(r.outbox, &memoryOutboxEntry{OutboxEntry: OutboxEntry{Event: copyEvent(event)}, seq: r.nextSeq})
+	return nil
+}
+
+func (r *InMemoryEventRepository) GetEventsByOrderID(ctx context.Context, orderID domain.OrderID) ([]*domain.Event, error) {
+	return r.GetEventsAfterVersion(ctx, orderID, 0)
+}
+
+func (r *InMemoryEventRepository) GetEventsAfterVersion(ctx context.Context, orderID domain.OrderID, afterVersion int64) ([]*domain.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var events []*domain.Event
+	for _, event := range r.streams[orderID] {
+		if event.AggregateVersion <= afterVersion {
+			continue
+		}
+		upcasted, err := domain.UpcastEvent(copyEvent(event))
+		if err != nil {
+			return nil, fmt.Errorf("upcast event %s: %w", event.EventID, err)
+		}
+		events = append(events, upcasted)
+	}
+	return events, nil
+}
+
+// ScanEvents walks the streams in order id order, so a cursor stays valid
+// while new orders are added.
+func (r *InMemoryEventRepository) ScanEvents(ctx context.Context, cursor string, limit int) ([]*domain.Event, string, error) {
+	start, err := parseEventCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	orderIDs := make([]string, 0, len(r.streams))
+	for orderID := range r.streams {
+		orderIDs = append(orderIDs, string(orderID))
+	}
+	sort.Strings(orderIDs)
+
+	var events []*domain.Event
+	for _, orderID := range orderIDs {
+		if start != nil && orderID < start.OrderID {
+			continue
+		}
+		for _, event := range r.streams[domain.OrderID(orderID)] {
+			if start != nil && orderID == start.OrderID && event.AggregateVersion <= start.AggregateVersion {
+				continue
+			}
+			if len(events) == limit {
+				last := events[len(events)-1]
+				return events, eventCursor{OrderID: string(last.OrderID), AggregateVersion: last.AggregateVersion}.String(), nil
+			}
+			events = append(events, copyEvent(event))
+		}
+	}
+	return events, "", nil
+}
+
+// The cursor is the insertion sequence of the last entry returned, so it stays
+// valid while earlier entries are published and removed.
+func (r *InMemoryEventRepository) GetPendingEvents(ctx context.Context, cursor string, limit int) ([]*OutboxEntry, string, error) {
+	var after int64
+	if cursor != "" {
+		parsed, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid outbox cursor %q", cursor)
+		}
+		after = parsed
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var entries []*OutboxEntry
+	for _, entry := range r.outbox {
+		if entry.seq <= after || entry.failed {
+			continue
+		}
+		if len(entries) == limit {
+			return entries, strconv.FormatInt(after, 10), nil
+		}
+		entries = append(entries, &OutboxEntry{Event: copyEvent(entry.Event), Attempts: entry.Attempts})
+		after = entry.seq
+	}
+	return entries, "", nil
+}
+
+// GetFailedEvents returns the entries given up on with MarkAsFailed.
+func (r *InMemoryEventRepository) GetFailedEvents(ctx context.Context) []*OutboxEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var entries []*OutboxEntry
+	for _, entry := range r.outbox {
+		if entry.failed {
+			entries = append(entries, &OutboxEntry{Event: copyEvent(entry.Event), Attempts: entry.Attempts})
+		}
+	}
+	return entries
+}
+
+func (r *InMemoryEventRepository) MarkAsPublished(ctx context.Context, eventID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, entry := range r.outbox {
+		if entry.Event.EventID == eventID {
+			r.outbox = append(r.outbox[:i], r.outbox[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *InMemoryEventRepository) RecordFailure(ctx context.Context, eventID string, cause error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range r.outbox {
+		if entry.Event.EventID == eventID {
+			entry.Attempts++
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *InMemoryEventRepository) MarkAsFailed(ctx context.Context, eventID string, cause error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range r.outbox {
+		if entry.Event.EventID == eventID {
+			entry.Attempts++
+			entry.failed = true
+			return nil
+		}
+	}
+	return nil
+}
+
+func copyEvent(event *domain.Event) *domain.Event {
+	copied := *event
+	copied.Data = append([]byte(nil), event.Data...)
+	return &copied
+}
@@ -0,0 +1,173 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+func TestInMemoryEventRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryEventRepository()
+
+	order, err := domain.NewOrder("order-123", "customer-456", []domain.LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: domain.Money{Amount: 100, Currency: "EUR"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created := domain.NewOrderCreatedEvent("event-1", "corr-1", order)
+
+	if err := repo.SaveEvent(ctx, created, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.SaveEvent(ctx, created, 0); !errors.Is(err, domain.ErrOrderAlreadyExists) {
+		t.Errorf("expected ErrOrderAlreadyExists, got %v", err)
+	}
+
+	confirmed, err := order.Confirm("event-2", "corr-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.SaveEvent(ctx, confirmed, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.SaveEvent(ctx, confirmed, 1); !errors.Is(err, domain.ErrConcurrencyConflict) {
+		t.Errorf("expected ErrConcurrencyConflict, got %v", err)
+	}
+
+	events, err := repo.GetEventsAfterVersion(ctx, "order-123", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 || events[0].EventID != "event-2" {
+		t.Errorf("expected only event-2 after version 1, got %d events", len(events))
+	}
+
+	pending, _, err := repo.GetPendingEvents(ctx, "", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("expected 2 outbox entries, got %d", len(pending))
+	}
+	if err := repo.MarkAsPublished(ctx, "event-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pending, _, _ := repo.GetPendingEvents(ctx, "", 10); len(pending) != 1 || pending[0].Event.EventID != "event-2" {
+		t.Errorf("expected only event-2 to remain in the outbox")
+	}
+}
+
+func TestInMemoryEventRepositoryOutboxPaging(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryEventRepository()
+	for _, id := range []domain.OrderID{"order-1", "order-2", "order-3"} {
+		order, err := domain.NewOrder(id, "customer-456", []domain.LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: domain.Money{Amount: 100, Currency: "EUR"}}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.SaveEvent(ctx, domain.NewOrderCreatedEvent("event-"+string(id), "corr-1", order), 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := repo.MarkAsFailed(ctx, "event-order-1", errors.New("rejected")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first, cursor, err := repo.GetPendingEvents(ctx, "", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(first) != 1 || first[0].Event.EventID != "event-order-2" || cursor == "" {
+		t.Fatalf("expected the failed entry to be skipped, got %d entries and cursor %q", len(first), cursor)
+	}
+	second, cursor, err := repo.GetPendingEvents(ctx, cursor, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(second) != 1 || second[0].Event.EventID != "event-order-3" || cursor != "" {
+		t.Errorf("expected the last entry and no cursor, got %d entries and cursor %q", len(second), cursor)
+	}
+
+	failed := repo.GetFailedEvents(ctx)
+	if len(failed) != 1 || failed[0].Event.EventID != "event-order-1" || failed[0].Attempts != 1 {
+		t.Errorf("expected event-order-1 to be marked as failed, got %+v", failed)
+	}
+}
+
+func TestInMemoryEventRepositoryScanEvents(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryEventRepository()
+	for _, id := range []domain.OrderID{"order-b", "order-a"} {
+		order, err := domain.NewOrder(id, "customer-456", []domain.LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: domain.Money{Amount: 100, Currency: "EUR"}}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.SaveEvent(ctx, domain.NewOrderCreatedEvent(string(id)+"-1", "corr-1", order), 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		confirmed, err := order.Confirm(string(id)+"-2", "corr-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.SaveEvent(ctx, confirmed, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	var scanned []string
+	cursor := ""
+	for pages := 1; ; pages++ {
+		events, next, err := repo.ScanEvents(ctx, cursor, 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, event := range events {
+			scanned = append(scanned, event.EventID)
+		}
+		if next == "" {
+			if pages != 2 {
+				t.Errorf("expected 2 pages, got %d", pages)
+			}
+			break
+		}
+		cursor = next
+	}
+
+	if got := strings.Join(scanned, ","); got != "order-a-1,order-a-2,order-b-1,order-b-2" {
+		t.Errorf("expected events in order id and version order, got %s", got)
+	}
+}
+
+func TestInProcessEventBus(t *testing.T) {
+	ctx := context.Background()
+	bus := NewInProcessEventBus(observability.NewLogger("", ""))
+
+	var received []*domain.Event
+	bus.Subscribe(func(ctx context.Context, event *domain.Event) error {
+		received = append(received, event)
+		return nil
+	})
+
+	order, err := domain.NewOrder("order-123", "customer-456", []domain.LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: domain.Money{Amount: 100, Currency: "EUR"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := bus.PublishEvent(ctx, domain.NewOrderCreatedEvent("event-1", "corr-1", order)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(received) != 1 || received[0].EventID != "event-1" || received[0].Version != domain.EventVersionV2 {
+		t.Errorf("expected the subscriber to receive event-1, got %+v", received)
+	}
+
+	invalid := &domain.Event{EventID: "event-2", Source: domain.EventSourceOrders, EventType: domain.EventTypeOrderCreated, Version: domain.EventVersionV2, Data: []byte(`{}`)}
+	if err := bus.PublishEvent(ctx, invalid); err == nil || domain.IsRetriable(err) {
+		t.Errorf("expected a non-retriable schema error, got %v", err)
+	}
+	if len(received) != 1 {
+		t.Errorf("invalid events must not reach subscribers")
+	}
+}
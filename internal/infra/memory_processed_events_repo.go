@@ -0,0 +1,43 @@
+package infra
+
+import (
+	"context"
+	"sync"
+)
+
+type InMemoryProcessedEventsRepository struct {
+	mu        *sync.Mutex
+	processed map[string]bool
+	consumer  string
+}
+
+func NewInMemoryProcessedEventsRepository(consumer string) *InMemoryProcessedEventsRepository {
+	return &InMemoryProcessedEventsRepository{
+		mu:        &sync.Mutex{},
+		processed: make(map[string]bool),
+		consumer:  consumer,
+	}
+}
+
+// ForConsumer returns a repository for another consumer that shares this
+// repository's storage, like a second consumer on the same DynamoDB table.
+func (r *InMemoryProcessedEventsRepository) ForConsumer(consumer string) *InMemoryProcessedEventsRepository {
+	return &InMemoryProcessedEventsRepository{
+		mu:        r.mu,
+		processed: r.processed,
+		consumer:  consumer,
+	}
+}
+
+func (r *InMemoryProcessedEventsRepository) MarkAsProcessed(ctx context.Context, eventID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.processed[processedEventKey(r.consumer, eventID)] = true
+	return nil
+}
+
+func (r *InMemoryProcessedEventsRepository) IsProcessed(ctx context.Context, eventID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.processed[processedEventKey(r.consumer, eventID)], nil
+}
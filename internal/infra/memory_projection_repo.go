@@ -0,0 +1,35 @@
+package infra
+
+import (
+	"context"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+)
+
+type InMemoryProjectionRepository struct {
+	*InMemoryReadModelRepository
+	*InMemoryProcessedEventsRepository
+}
+
+func NewInMemoryProjectionRepository(processed *InMemoryProcessedEventsRepository) *InMemoryProjectionRepository {
+	return &InMemoryProjectionRepository{
+		InMemoryReadModelRepository:       NewInMemoryReadModelRepository(),
+		InMemoryProcessedEventsRepository: processed,
+	}
+}
+
+func (r *InMemoryProjectionRepository) SaveOrderAndMarkProcessed(ctx context.Context, order *domain.Order, eventID string) error {
+	processed := r.InMemoryProcessedEventsRepository
+	processed.mu.Lock()
+	defer processed.mu.Unlock()
+
+	key := processedEventKey(processed.consumer, eventID)
+	if processed.processed[key] {
+		return domain.ErrEventAlreadyProcessed
+	}
+	if err := r.InMemoryReadModelRepository.SaveOrder(ctx, order); err != nil {
+		return err
+	}
+	processed.processed[key] = true
+	return nil
+}
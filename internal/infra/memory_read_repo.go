@@ -0,0 +1,44 @@
+package infra
+
+import (
+	"context"
+	"sync"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+)
+
+type InMemoryReadModelRepository struct {
+	mu     sync.Mutex
+	orders map[domain.OrderID]domain.Order
+}
+
+func NewInMemoryReadModelRepository() *InMemoryReadModelRepository {
+	return &InMemoryReadModelRepository{
+		orders: make(map[domain.OrderID]domain.Order),
+	}
+}
+
+func (r *InMemoryReadModelRepository) SaveOrder(ctx context.Context, order *domain.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.orders[order.ID]; ok && order.Version > 0 && existing.Version >= order.Version {
+		return domain.ErrStaleEvent
+	}
+	copied := *order
+	copied.Items = append([]domain.LineItem(nil), order.Items...)
+	r.orders[order.ID] = copied
+	return nil
+}
+
+func (r *InMemoryReadModelRepository) GetOrder(ctx context.Context, orderID domain.OrderID) (*domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, ok := r.orders[orderID]
+	if !ok {
+		return nil, nil
+	}
+	order.Items = append([]domain.LineItem(nil), order.Items...)
+	return &order, nil
+}
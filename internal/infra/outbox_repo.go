@@ -0,0 +1,183 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+const outboxStatusFailed = "failed"
+
+type DynamoDBOutboxRepository struct {
+	client    DynamoDBOutboxAPI
+	tableName string
+	logger    *observability.Logger
+}
+
+func NewDynamoDBOutboxRepository(client DynamoDBOutboxAPI, tableName string, logger *observability.Logger) *DynamoDBOutboxRepository {
+	return &DynamoDBOutboxRepository{
+		client:    client,
+		tableName: tableName,
+		logger:    logger,
+	}
+}
+
+type OutboxItem struct {
+	EventID   string `dynamodbav:"event_id"`
+	OrderID   string `dynamodbav:"order_id"`
+	Event     string `dynamodbav:"event"`
+	Attempts  int    `dynamodbav:"attempts"`
+	Status    string `dynamodbav:"status,omitempty"`
+	LastError string `dynamodbav:"last_error,omitempty"`
+	CreatedAt string `dynamodbav:"created_at"`
+}
+
+func newOutboxItem(event *domain.Event) (map[string]types.AttributeValue, error) {
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("marshal outbox event: %w", err)
+	}
+
+	item := OutboxItem{
+		EventID:   event.EventID,
+		OrderID:   string(event.OrderID),
+		Event:     string(eventJSON),
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return nil, fmt.Errorf("marshal outbox item: %w", err)
+	}
+	return av, nil
+}
+
+// Limit applies before the status filter, so a page can come back short or
+// even empty while the cursor still points at more entries.
+func (r *DynamoDBOutboxRepository) GetPendingEvents(ctx context.Context, cursor string, limit int) ([]*OutboxEntry, string, error) {
+	input := &dynamodb.ScanInput{
+		TableName:                aws.String(r.tableName),
+		Limit:                    aws.Int32(int32(limit)),
+		ConsistentRead:           aws.Bool(true),
+		FilterExpression:         aws.String("attribute_not_exists(#status) OR #status <> :failed"),
+		ExpressionAttributeNames: map[string]string{"#status": "status"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":failed": &types.AttributeValueMemberS{Value: outboxStatusFailed},
+		},
+	}
+	if cursor != "" {
+		input.ExclusiveStartKey = map[string]types.AttributeValue{
+			"event_id": &types.AttributeValueMemberS{Value: cursor},
+		}
+	}
+
+	result, err := r.client.Scan(ctx, input)
+	if err != nil {
+		r.logger.Error("failed to scan outbox", err)
+		return nil, "", fmt.Errorf("scan outbox: %w", err)
+	}
+
+	entries := make([]*OutboxEntry, 0, len(result.Items))
+	for _, item := range result.Items {
+		var outboxItem OutboxItem
+		if err := attributevalue.UnmarshalMap(item, &outboxItem); err != nil {
+			r.logger.Error("failed to unmarshal outbox item", err)
+			continue
+		}
+
+		var event domain.Event
+		if err := json.Unmarshal([]byte(outboxItem.Event), &event); err != nil {
+			r.logger.Error("failed to unmarshal outbox event", err, map[string]interface{}{
+				"event_id": outboxItem.EventID,
+			})
+			continue
+		}
+
+		entries = append(entries, &OutboxEntry{
+			Event:    &event,
+			Attempts: outboxItem.Attempts,
+		})
+	}
+
+	next := ""
+	if key, ok := result.LastEvaluatedKey["event_id"].(*types.AttributeValueMemberS); ok {
+		next = key.Value
+	}
+	return entries, next, nil
+}
+
+func (r *DynamoDBOutboxRepository) MarkAsPublished(ctx context.Context, eventID string) error {
+	_, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"event_id": &types.AttributeValueMemberS{Value: eventID},
+		},
+	})
+
+	if err != nil {
+		r.logger.Error("failed to delete outbox entry", err, map[string]interface{}{
+			"event_id": eventID,
+		})
+		return fmt.Errorf("delete outbox entry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DynamoDBOutboxRepository) RecordFailure(ctx context.Context, eventID string, cause error) error {
+	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"event_id": &types.AttributeValueMemberS{Value: eventID},
+		},
+		UpdateExpression:    aws.String("SET attempts = attempts + :one, last_error = :last_error"),
+		ConditionExpression: aws.String("attribute_exists(event_id)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":one":        &types.AttributeValueMemberN{Value: "1"},
+			":last_error": &types.AttributeValueMemberS{Value: cause.Error()},
+		},
+	})
+
+	if err != nil {
+		r.logger.Error("failed to record outbox failure", err, map[string]interface{}{
+			"event_id": eventID,
+		})
+		return fmt.Errorf("record outbox failure: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DynamoDBOutboxRepository) MarkAsFailed(ctx context.Context, eventID string, cause error) error {
+	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"event_id": &types.AttributeValueMemberS{Value: eventID},
+		},
+		UpdateExpression:         aws.String("SET #status = :failed, attempts = attempts + :one, last_error = :last_error"),
+		ConditionExpression:      aws.String("attribute_exists(event_id)"),
+		ExpressionAttributeNames: map[string]string{"#status": "status"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":failed":     &types.AttributeValueMemberS{Value: outboxStatusFailed},
+			":one":        &types.AttributeValueMemberN{Value: "1"},
+			":last_error": &types.AttributeValueMemberS{Value: cause.Error()},
+		},
+	})
+
+	if err != nil {
+		r.logger.Error("failed to mark outbox entry as failed", err, map[string]interface{}{
+			"event_id": eventID,
+		})
+		return fmt.Errorf("mark outbox entry as failed: %w", err)
+	}
+
+	return nil
+}
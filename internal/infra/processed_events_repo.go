@@ -13,51 +13,61 @@ import (
 	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
 )
 
+const defaultProcessedEventsTTLDays = 90
+
+// DynamoDBProcessedEventsRepository tracks processed events per consumer. The
+// table's event_id key holds consumer#event_id, so several consumers can
+// share one table without skipping each other's events.
 type DynamoDBProcessedEventsRepository struct {
-	client    *dynamodb.Client
-	tableName string
-	logger    *observability.Logger
-	ttlDays   int
+	client     DynamoDBItemAPI
+	tableName  string
+	consumer   string
+	legacyKeys bool
+	logger     *observability.Logger
+	ttlDays    int
 }
 
-func NewDynamoDBProcessedEventsRepository(client *dynamodb.Client, tableName string, logger *observability.Logger) *DynamoDBProcessedEventsRepository {
-	return &DynamoDBProcessedEventsRepository{
-		client:    client,
-		tableName: tableName,
-		logger:    logger,
-		ttlDays:   90,
-	}
+func NewDynamoDBProcessedEventsRepository(client DynamoDBItemAPI, tableName, consumer string, logger *observability.Logger) *DynamoDBProcessedEventsRepository {
+	return NewDynamoDBProcessedEventsRepositoryWithTTL(client, tableName, consumer, logger, defaultProcessedEventsTTLDays)
 }
 
-func NewDynamoDBProcessedEventsRepositoryWithTTL(client *dynamodb.Client, tableName string, logger *observability.Logger, ttlDays int) *DynamoDBProcessedEventsRepository {
+func NewDynamoDBProcessedEventsRepositoryWithTTL(client DynamoDBItemAPI, tableName, consumer string, logger *observability.Logger, ttlDays int) *DynamoDBProcessedEventsRepository {
 	return &DynamoDBProcessedEventsRepository{
 		client:    client,
 		tableName: tableName,
+		consumer:  consumer,
 		logger:    logger,
 		ttlDays:   ttlDays,
 	}
 }
 
-type ProcessedEventItem struct {
-	EventID     string `dynamodbav:"event_id"`
-	ProcessedAt string `dynamodbav:"processed_at"`
-	TTL         int64  `dynamodbav:"ttl,omitempty"`
+// NewDynamoDBProcessedEventsRepositoryWithLegacyKeys also treats rows keyed by
+// the bare event id, written before markers were scoped per consumer, as
+// processed. Only the consumer that wrote those rows should use it, and only
+// until they have expired through the table TTL.
+func NewDynamoDBProcessedEventsRepositoryWithLegacyKeys(client DynamoDBItemAPI, tableName, consumer string, logger *observability.Logger) *DynamoDBProcessedEventsRepository {
+	r := NewDynamoDBProcessedEventsRepository(client, tableName, consumer, logger)
+	r.legacyKeys = true
+	return r
 }
 
-func (r *DynamoDBProcessedEventsRepository) MarkAsProcessed(ctx context.Context, eventID string) error {
-	now := time.Now().UTC()
-	ttl := now.AddDate(0, 0, r.ttlDays).Unix()
+type ProcessedEventItem struct {
+	EventID       string `dynamodbav:"event_id"`
+	Consumer      string `dynamodbav:"consumer,omitempty"`
+	SourceEventID string `dynamodbav:"source_event_id,omitempty"`
+	ProcessedAt   string `dynamodbav:"processed_at"`
+	TTL           int64  `dynamodbav:"ttl,omitempty"`
+}
 
-	item := ProcessedEventItem{
-		EventID:     eventID,
-		ProcessedAt: now.Format(time.RFC3339),
-		TTL:         ttl,
-	}
+func processedEventKey(consumer, eventID string) string {
+	return consumer + "#" + eventID
+}
 
-	av, err := attributevalue.MarshalMap(item)
+func (r *DynamoDBProcessedEventsRepository) MarkAsProcessed(ctx context.Context, eventID string) error {
+	av, err := r.newProcessedEventItem(eventID)
 	if err != nil {
 		r.logger.Error("failed to marshal processed event", err)
-		return fmt.Errorf("marshal processed event: %w", err)
+		return err
 	}
 
 	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
@@ -71,36 +81,65 @@ func (r *DynamoDBProcessedEventsRepository) MarkAsProcessed(ctx context.Context,
 		if errors.As(err, &condCheckErr) {
 			r.logger.Warn("event already processed", map[string]interface{}{
 				"event_id": eventID,
+				"consumer": r.consumer,
 			})
 			return nil
 		}
 		r.logger.Error("failed to mark event as processed", err, map[string]interface{}{
 			"event_id": eventID,
+			"consumer": r.consumer,
 		})
 		return fmt.Errorf("mark event as processed: %w", err)
 	}
 
 	r.logger.Info("event marked as processed", map[string]interface{}{
 		"event_id": eventID,
+		"consumer": r.consumer,
 	})
 
 	return nil
 }
 
 func (r *DynamoDBProcessedEventsRepository) IsProcessed(ctx context.Context, eventID string) (bool, error) {
+	processed, err := r.hasMarker(ctx, processedEventKey(r.consumer, eventID))
+	if err != nil || processed || !r.legacyKeys {
+		return processed, err
+	}
+	return r.hasMarker(ctx, eventID)
+}
+
+func (r *DynamoDBProcessedEventsRepository) hasMarker(ctx context.Context, key string) (bool, error) {
 	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: aws.String(r.tableName),
 		Key: map[string]types.AttributeValue{
-			"event_id": &types.AttributeValueMemberS{Value: eventID},
+			"event_id": &types.AttributeValueMemberS{Value: key},
 		},
 	})
 
 	if err != nil {
 		r.logger.Error("failed to check if event is processed", err, map[string]interface{}{
-			"event_id": eventID,
+			"key":      key,
+			"consumer": r.consumer,
 		})
 		return false, fmt.Errorf("check processed event: %w", err)
 	}
 
 	return result.Item != nil, nil
 }
+
+func (r *DynamoDBProcessedEventsRepository) newProcessedEventItem(eventID string) (map[string]types.AttributeValue, error) {
+	now := time.Now().UTC()
+	item := ProcessedEventItem{
+		EventID:       processedEventKey(r.consumer, eventID),
+		Consumer:      r.consumer,
+		SourceEventID: eventID,
+		ProcessedAt:   now.Format(time.RFC3339),
+		TTL:           now.AddDate(0, 0, r.ttlDays).Unix(),
+	}
+
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return nil, fmt.Errorf("marshal processed event: %w", err)
+	}
+	return av, nil
+}
@@ -0,0 +1,122 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+type DynamoDBProjectionRepository struct {
+	*DynamoDBReadModelRepository
+	*DynamoDBProcessedEventsRepository
+	client        DynamoDBProjectionAPI
+	readTableName string
+	overwrite     bool
+	logger        *observability.Logger
+}
+
+func NewDynamoDBProjectionRepository(client DynamoDBProjectionAPI, readTableName string, processed *DynamoDBProcessedEventsRepository, logger *observability.Logger) *DynamoDBProjectionRepository {
+	return &DynamoDBProjectionRepository{
+		DynamoDBReadModelRepository:       NewDynamoDBReadModelRepository(client, readTableName, logger),
+		DynamoDBProcessedEventsRepository: processed,
+		client:                            client,
+		readTableName:                     readTableName,
+		logger:                            logger,
+	}
+}
+
+// NewDynamoDBProjectionRepositoryWithOverwrite writes orders without the
+// version check, for rebuilding a read model that already holds newer rows.
+// Nothing else may write to the table during the rebuild.
+func NewDynamoDBProjectionRepositoryWithOverwrite(client DynamoDBProjectionAPI, readTableName string, processed *DynamoDBProcessedEventsRepository, logger *observability.Logger) *DynamoDBProjectionRepository {
+	repo := NewDynamoDBProjectionRepository(client, readTableName, processed, logger)
+	repo.overwrite = true
+	return repo
+}
+
+func (r *DynamoDBProjectionRepository) SaveOrderAndMarkProcessed(ctx context.Context, order *domain.Order, eventID string) error {
+	orderAV, err := newOrderItem(order)
+	if err != nil {
+		r.logger.Error("failed to marshal order", err)
+		return err
+	}
+
+	version := order.Version
+	if r.overwrite {
+		version = 0
+	}
+
+	processed := r.DynamoDBProcessedEventsRepository
+	markerAV, err := processed.newProcessedEventItem(eventID)
+	if err != nil {
+		r.logger.Error("failed to marshal processed event", err)
+		return err
+	}
+
+	transactItems := []types.TransactWriteItem{
+		{
+			Put: newOrderPut(r.readTableName, orderAV, version),
+		},
+		{
+			Put: &types.Put{
+				TableName:           aws.String(processed.tableName),
+				Item:                markerAV,
+				ConditionExpression: aws.String("attribute_not_exists(event_id)"),
+			},
+		},
+	}
+	// A delivery that raced the migration to scoped markers may have left only
+	// a bare event_id marker; the IsProcessed pre-check alone would let a
+	// concurrent redelivery apply the event twice.
+	if processed.legacyKeys {
+		transactItems = append(transactItems, types.TransactWriteItem{
+			ConditionCheck: &types.ConditionCheck{
+				TableName: aws.String(processed.tableName),
+				Key: map[string]types.AttributeValue{
+					"event_id": &types.AttributeValueMemberS{Value: eventID},
+				},
+				ConditionExpression: aws.String("attribute_not_exists(event_id)"),
+			},
+		})
+	}
+
+	_, err = r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+
+	if err != nil {
+		if conditionalCheckFailedAt(err, 1) || (processed.legacyKeys && conditionalCheckFailedAt(err, 2)) {
+			r.logger.Warn("event already processed", map[string]interface{}{
+				"event_id": eventID,
+				"order_id": order.ID,
+				"consumer": processed.consumer,
+			})
+			return domain.ErrEventAlreadyProcessed
+		}
+		if conditionalCheckFailedAt(err, 0) {
+			r.logger.Warn("stale read model write", map[string]interface{}{
+				"event_id": eventID,
+				"order_id": order.ID,
+				"version":  order.Version,
+			})
+			return domain.ErrStaleEvent
+		}
+		r.logger.Error("failed to save projection", err, map[string]interface{}{
+			"event_id": eventID,
+			"order_id": order.ID,
+		})
+		return fmt.Errorf("save projection: %w", err)
+	}
+
+	r.logger.Info("order saved to read model", map[string]interface{}{
+		"order_id": order.ID,
+		"event_id": eventID,
+	})
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+func encodeForPublish(eventTypes *domain.EventTypeRegistry, format domain.EventFormat, event *domain.Event, logger *observability.Logger) (json.RawMessage, error) {
+	detailJSON := event.ToEventBridgeDetail()
+	if !json.Valid(detailJSON) {
+		return nil, fmt.Errorf("invalid event detail for event %s", event.EventID)
+	}
+
+	if err := eventTypes.Validate(event); err != nil {
+		logger.Error("event rejected by schema registry", err, map[string]interface{}{
+			"event_id":   event.EventID,
+			"event_type": event.EventType,
+			"version":    event.Version,
+		})
+		return nil, domain.NewNonRetriableError(err, "event payload rejected by schema registry")
+	}
+
+	encoded, err := event.Encode(format)
+	if err != nil {
+		return nil, domain.NewNonRetriableError(err, "failed to encode event")
+	}
+	return encoded, nil
+}
+
+func publishConcurrently(ctx context.Context, events []*domain.Event, concurrency int, publish func(context.Context, *domain.Event) error) []PublishResult {
+	results := make([]PublishResult, len(events))
+	forEachConcurrently(len(events), concurrency, func(i int) {
+		results[i] = PublishResult{Event: events[i], Err: publish(ctx, events[i])}
+	})
+	return results
+}
+
+func forEachConcurrently(n, concurrency int, fn func(i int)) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func logPublishResults(logger *observability.Logger, target string, results []PublishResult) {
+	published := 0
+	for _, result := range results {
+		if result.Err != nil {
+			logger.Error("failed to publish event", result.Err, map[string]interface{}{
+				"target":   target,
+				"event_id": result.Event.EventID,
+				"order_id": result.Event.OrderID,
+			})
+			continue
+		}
+		published++
+		logger.Info("event published", map[string]interface{}{
+			"target":   target,
+			"event_id": result.Event.EventID,
+			"order_id": result.Event.OrderID,
+		})
+	}
+
+	if len(results) > 1 {
+		logger.Info("event batch published", map[string]interface{}{
+			"target":    target,
+			"events":    len(results),
+			"published": published,
+			"failed":    len(results) - published,
+		})
+	}
+}
+
+func firstResultError(results []PublishResult) error {
+	if results[0].Err != nil {
+		return fmt.Errorf("publish event: %w", results[0].Err)
+	}
+	return nil
+}
+
+const (
+	defaultPublishMaxAttempts = 3
+	defaultPublishBackoff     = 100 * time.Millisecond
+	defaultPublishConcurrency = 4
+)
+
+func sendWithRetry(ctx context.Context, size, maxAttempts int, backoff time.Duration, logger *observability.Logger, send func(ctx context.Context, pending []int) []error) []error {
+	errs := make([]error, size)
+	pending := make([]int, size)
+	for i := range pending {
+		pending[i] = i
+	}
+
+	for attempt := 1; ; attempt++ {
+		// Only entries that failed with a retriable error are resent.
+		var retry []int
+		for i, err := range send(ctx, pending) {
+			idx := pending[i]
+			errs[idx] = err
+			if err != nil && domain.IsRetriable(err) {
+				retry = append(retry, idx)
+			}
+		}
+		if len(retry) == 0 || attempt >= maxAttempts {
+			return errs
+		}
+
+		logger.Warn("retrying failed publish entries", map[string]interface{}{
+			"failed_entries": len(retry),
+			"attempt":        attempt,
+		})
+
+		select {
+		case <-ctx.Done():
+			for _, idx := range retry {
+				errs[idx] = ctx.Err()
+			}
+			return errs
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		pending = retry
+	}
+}
+
+func chunkBySize(sizes []int, maxEntries, maxBytes int) ([][]int, []int) {
+	var chunks [][]int
+	var oversized []int
+	var current []int
+	currentBytes := 0
+
+	for i, size := range sizes {
+		if size > maxBytes {
+			oversized = append(oversized, i)
+			continue
+		}
+		if len(current) == maxEntries || currentBytes+size > maxBytes {
+			chunks = append(chunks, current)
+			current, currentBytes = nil, 0
+		}
+		current = append(current, i)
+		currentBytes += size
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+
+	return chunks, oversized
+}
+
+func oversizedEventError(maxBytes int) error {
+	return domain.NewNonRetriableError(fmt.Errorf("entry exceeds %d bytes", maxBytes), "event too large to publish")
+}
+
+func batchEntryError(code, message string, senderFault bool) error {
+	err := fmt.Errorf("batch entry failed: %s: %s", code, message)
+	if senderFault {
+		return domain.NewNonRetriableError(err, "batch entry rejected")
+	}
+	return domain.NewRetriableError(err, "batch entry failed")
+}
+
+// unknownBatchEntryError fails every entry of a batch whose response reports
+// a failure for an id that was not sent, since the failed entries cannot be
+// identified. Retrying may deliver the others twice; consumers deduplicate.
+func unknownBatchEntryError(id string) error {
+	return domain.NewRetriableError(fmt.Errorf("batch response reports unknown entry id %q", id), "batch entry results unmatched")
+}
+
+func eventAttributes(event *domain.Event) map[string]string {
+	attributes := map[string]string{
+		"event_id":   event.EventID,
+		"event_type": event.EventType,
+		"source":     event.Source,
+		"version":    event.Version,
+		"order_id":   string(event.OrderID),
+	}
+	if event.CorrelationID != "" {
+		attributes["correlation_id"] = event.CorrelationID
+	}
+	return attributes
+}
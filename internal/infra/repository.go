@@ -3,24 +3,59 @@ package infra
 import (
 	"context"
 
-	"github.com/stevenbode/go-serverless-event-platform/internal/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/platform"
 )
 
-type EventRepository interface {
-	SaveEvent(ctx context.Context, event *domain.Event) error
-	GetEventsByOrderID(ctx context.Context, orderID domain.OrderID) ([]*domain.Event, error)
+// The contracts shared with pkg/testkit are defined in pkg/platform.
+type (
+	EventRepository           = platform.EventRepository
+	OrderRepository           = platform.OrderRepository
+	PublishResult             = platform.PublishResult
+	EventPublisher            = platform.EventPublisher
+	ReadModelRepository       = platform.ReadModelRepository
+	ProcessedEventsRepository = platform.ProcessedEventsRepository
+	ProjectionRepository      = platform.ProjectionRepository
+	OutboxEntry               = platform.OutboxEntry
+	OutboxRepository          = platform.OutboxRepository
+)
+
+// EventStoreScanner pages through every stored event, as stored and without
+// upcasting. Pass an empty cursor to start at the beginning; an empty next
+// cursor means the scan is complete.
+type EventStoreScanner interface {
+	ScanEvents(ctx context.Context, cursor string, limit int) ([]*domain.Event, string, error)
+}
+
+// ReplayCheckpoint records how far a replay run got. The run ID scopes the
+// run's processed-event markers: a resumed run skips what it already
+// projected, a new run starts without markers.
+type ReplayCheckpoint struct {
+	RunID  string `json:"run_id"`
+	Cursor string `json:"cursor"`
+}
+
+// LoadCheckpoint returns nil when no replay is in progress.
+type CheckpointStore interface {
+	LoadCheckpoint(ctx context.Context) (*ReplayCheckpoint, error)
+	SaveCheckpoint(ctx context.Context, checkpoint *ReplayCheckpoint) error
 }
 
-type EventPublisher interface {
-	PublishEvent(ctx context.Context, event *domain.Event) error
+type SnapshotRepository interface {
+	SaveSnapshot(ctx context.Context, snapshot *domain.OrderSnapshot) error
+	GetLatestSnapshot(ctx context.Context, orderID domain.OrderID) (*domain.OrderSnapshot, error)
 }
 
-type ReadModelRepository interface {
-	SaveOrder(ctx context.Context, order *domain.Order) error
-	GetOrder(ctx context.Context, orderID domain.OrderID) (*domain.Order, error)
+type IdempotencyRecord struct {
+	Key          string
+	RequestHash  string
+	Status       string
+	StatusCode   int
+	ResponseBody string
 }
 
-type ProcessedEventsRepository interface {
-	MarkAsProcessed(ctx context.Context, eventID string) error
-	IsProcessed(ctx context.Context, eventID string) (bool, error)
+type IdempotencyRepository interface {
+	Acquire(ctx context.Context, key, requestHash string) (*IdempotencyRecord, error)
+	Complete(ctx context.Context, key string, statusCode int, responseBody string) error
+	Release(ctx context.Context, key string) error
 }
@@ -0,0 +1,113 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+type DynamoDBSnapshotRepository struct {
+	client    DynamoDBItemAPI
+	tableName string
+	logger    *observability.Logger
+}
+
+func NewDynamoDBSnapshotRepository(client DynamoDBItemAPI, tableName string, logger *observability.Logger) *DynamoDBSnapshotRepository {
+	return &DynamoDBSnapshotRepository{
+		client:    client,
+		tableName: tableName,
+		logger:    logger,
+	}
+}
+
+type SnapshotItem struct {
+	OrderID       string `dynamodbav:"order_id"`
+	Version       int64  `dynamodbav:"version"`
+	SchemaVersion int    `dynamodbav:"schema_version"`
+	State         string `dynamodbav:"state"`
+	CreatedAt     string `dynamodbav:"created_at"`
+}
+
+func (r *DynamoDBSnapshotRepository) SaveSnapshot(ctx context.Context, snapshot *domain.OrderSnapshot) error {
+	item := SnapshotItem{
+		OrderID:       string(snapshot.OrderID),
+		Version:       snapshot.Version,
+		SchemaVersion: snapshot.SchemaVersion,
+		State:         string(snapshot.State),
+		CreatedAt:     snapshot.CreatedAt.Format(time.RFC3339),
+	}
+
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		r.logger.Error("failed to marshal snapshot", err)
+		return fmt.Errorf("marshal snapshot: %w", err)
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(r.tableName),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(order_id) OR version < :version OR schema_version < :schema_version"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":version":        &types.AttributeValueMemberN{Value: strconv.FormatInt(snapshot.Version, 10)},
+			":schema_version": &types.AttributeValueMemberN{Value: strconv.Itoa(snapshot.SchemaVersion)},
+		},
+	})
+
+	if err != nil {
+		var condCheckErr *types.ConditionalCheckFailedException
+		if errors.As(err, &condCheckErr) {
+			return nil
+		}
+		r.logger.Error("failed to save snapshot", err, map[string]interface{}{
+			"order_id": snapshot.OrderID,
+		})
+		return fmt.Errorf("save snapshot: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DynamoDBSnapshotRepository) GetLatestSnapshot(ctx context.Context, orderID domain.OrderID) (*domain.OrderSnapshot, error) {
+	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"order_id": &types.AttributeValueMemberS{Value: string(orderID)},
+		},
+	})
+
+	if err != nil {
+		r.logger.Error("failed to get snapshot", err, map[string]interface{}{
+			"order_id": orderID,
+		})
+		return nil, fmt.Errorf("get snapshot: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var item SnapshotItem
+	if err := attributevalue.UnmarshalMap(result.Item, &item); err != nil {
+		r.logger.Error("failed to unmarshal snapshot", err)
+		return nil, fmt.Errorf("unmarshal snapshot: %w", err)
+	}
+
+	createdAt, _ := time.Parse(time.RFC3339, item.CreatedAt)
+
+	return &domain.OrderSnapshot{
+		OrderID:       domain.OrderID(item.OrderID),
+		Version:       item.Version,
+		SchemaVersion: item.SchemaVersion,
+		State:         []byte(item.State),
+		CreatedAt:     createdAt,
+	}, nil
+}
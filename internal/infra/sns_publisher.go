@@ -0,0 +1,151 @@
+package infra
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+const (
+	snsBatchMaxEntries      = 10
+	snsBatchMaxRequestBytes = 256 * 1024
+)
+
+type SNSPublisher struct {
+	client      SNSAPI
+	topicARN    string
+	fifo        bool
+	eventTypes  *domain.EventTypeRegistry
+	format      domain.EventFormat
+	logger      *observability.Logger
+	maxAttempts int
+	backoff     time.Duration
+	concurrency int
+}
+
+func NewSNSPublisher(client SNSAPI, topicARN string, format domain.EventFormat, logger *observability.Logger) *SNSPublisher {
+	return &SNSPublisher{
+		client:      client,
+		topicARN:    topicARN,
+		fifo:        strings.HasSuffix(topicARN, ".fifo"),
+		eventTypes:  domain.DefaultEventTypes(),
+		format:      format,
+		logger:      logger,
+		maxAttempts: defaultPublishMaxAttempts,
+		backoff:     defaultPublishBackoff,
+		concurrency: defaultPublishConcurrency,
+	}
+}
+
+func (p *SNSPublisher) PublishEvent(ctx context.Context, event *domain.Event) error {
+	return firstResultError(p.PublishEvents(ctx, []*domain.Event{event}))
+}
+
+func (p *SNSPublisher) PublishEvents(ctx context.Context, events []*domain.Event) []PublishResult {
+	results := make([]PublishResult, len(events))
+	entries := make([]types.PublishBatchRequestEntry, 0, len(events))
+	sizes := make([]int, 0, len(events))
+	positions := make([]int, 0, len(events))
+
+	for i, event := range events {
+		results[i].Event = event
+		message, err := encodeForPublish(p.eventTypes, p.format, event, p.logger)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		entry := types.PublishBatchRequestEntry{
+			Message:           aws.String(string(message)),
+			MessageAttributes: snsMessageAttributes(event),
+		}
+		if p.fifo {
+			entry.MessageGroupId = aws.String(string(event.OrderID))
+			entry.MessageDeduplicationId = aws.String(event.EventID)
+		}
+
+		size := len(message)
+		for name, value := range entry.MessageAttributes {
+			size += len(name) + len(aws.ToString(value.DataType)) + len(aws.ToString(value.StringValue))
+		}
+
+		entries = append(entries, entry)
+		sizes = append(sizes, size)
+		positions = append(positions, i)
+	}
+
+	chunks, oversized := chunkBySize(sizes, snsBatchMaxEntries, snsBatchMaxRequestBytes)
+	for _, idx := range oversized {
+		results[positions[idx]].Err = oversizedEventError(snsBatchMaxRequestBytes)
+	}
+
+	concurrency := p.concurrency
+	if p.fifo {
+		concurrency = 1
+	}
+	forEachConcurrently(len(chunks), concurrency, func(c int) {
+		chunk := chunks[c]
+		errs := sendWithRetry(ctx, len(chunk), p.maxAttempts, p.backoff, p.logger, func(ctx context.Context, pending []int) []error {
+			batch := make([]types.PublishBatchRequestEntry, len(pending))
+			for i, idx := range pending {
+				batch[i] = entries[chunk[idx]]
+				batch[i].Id = aws.String(strconv.Itoa(i))
+			}
+			return p.publishBatch(ctx, batch)
+		})
+		for i, err := range errs {
+			results[positions[chunk[i]]].Err = err
+		}
+	})
+
+	logPublishResults(p.logger, "sns", results)
+
+	return results
+}
+
+func (p *SNSPublisher) publishBatch(ctx context.Context, batch []types.PublishBatchRequestEntry) []error {
+	errs := make([]error, len(batch))
+
+	output, err := p.client.PublishBatch(ctx, &sns.PublishBatchInput{
+		TopicArn:                   aws.String(p.topicARN),
+		PublishBatchRequestEntries: batch,
+	})
+	if err != nil {
+		requestErr := domain.NewRetriableError(err, "publish batch failed")
+		for i := range errs {
+			errs[i] = requestErr
+		}
+		return errs
+	}
+
+	for _, failed := range output.Failed {
+		i, convErr := strconv.Atoi(aws.ToString(failed.Id))
+		if convErr != nil || i < 0 || i >= len(errs) {
+			batchErr := unknownBatchEntryError(aws.ToString(failed.Id))
+			for i := range errs {
+				errs[i] = batchErr
+			}
+			return errs
+		}
+		errs[i] = batchEntryError(aws.ToString(failed.Code), aws.ToString(failed.Message), failed.SenderFault)
+	}
+	return errs
+}
+
+func snsMessageAttributes(event *domain.Event) map[string]types.MessageAttributeValue {
+	attributes := make(map[string]types.MessageAttributeValue)
+	for name, value := range eventAttributes(event) {
+		attributes[name] = types.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(value),
+		}
+	}
+	return attributes
+}
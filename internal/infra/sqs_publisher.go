@@ -0,0 +1,154 @@
+package infra
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+)
+
+const (
+	sqsBatchMaxEntries      = 10
+	sqsBatchMaxRequestBytes = 256 * 1024
+)
+
+type SQSPublisher struct {
+	client      SQSAPI
+	queueURL    string
+	fifo        bool
+	eventTypes  *domain.EventTypeRegistry
+	format      domain.EventFormat
+	logger      *observability.Logger
+	maxAttempts int
+	backoff     time.Duration
+	concurrency int
+}
+
+func NewSQSPublisher(client SQSAPI, queueURL string, format domain.EventFormat, logger *observability.Logger) *SQSPublisher {
+	return &SQSPublisher{
+		client:      client,
+		queueURL:    queueURL,
+		fifo:        strings.HasSuffix(queueURL, ".fifo"),
+		eventTypes:  domain.DefaultEventTypes(),
+		format:      format,
+		logger:      logger,
+		maxAttempts: defaultPublishMaxAttempts,
+		backoff:     defaultPublishBackoff,
+		concurrency: defaultPublishConcurrency,
+	}
+}
+
+func (p *SQSPublisher) PublishEvent(ctx context.Context, event *domain.Event) error {
+	return firstResultError(p.PublishEvents(ctx, []*domain.Event{event}))
+}
+
+func (p *SQSPublisher) PublishEvents(ctx context.Context, events []*domain.Event) []PublishResult {
+	results := make([]PublishResult, len(events))
+	entries := make([]types.SendMessageBatchRequestEntry, 0, len(events))
+	sizes := make([]int, 0, len(events))
+	positions := make([]int, 0, len(events))
+
+	for i, event := range events {
+		results[i].Event = event
+		body, err := encodeForPublish(p.eventTypes, p.format, event, p.logger)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		entry := types.SendMessageBatchRequestEntry{
+			MessageBody:       aws.String(string(body)),
+			MessageAttributes: sqsMessageAttributes(event),
+		}
+		// FIFO queues keep per-order ordering and drop redelivered outbox entries.
+		if p.fifo {
+			entry.MessageGroupId = aws.String(string(event.OrderID))
+			entry.MessageDeduplicationId = aws.String(event.EventID)
+		}
+
+		size := len(body)
+		for name, value := range entry.MessageAttributes {
+			size += len(name) + len(aws.ToString(value.DataType)) + len(aws.ToString(value.StringValue))
+		}
+
+		entries = append(entries, entry)
+		sizes = append(sizes, size)
+		positions = append(positions, i)
+	}
+
+	chunks, oversized := chunkBySize(sizes, sqsBatchMaxEntries, sqsBatchMaxRequestBytes)
+	for _, idx := range oversized {
+		results[positions[idx]].Err = oversizedEventError(sqsBatchMaxRequestBytes)
+	}
+
+	// FIFO batches run one after another so a group is never split across
+	// concurrent requests.
+	concurrency := p.concurrency
+	if p.fifo {
+		concurrency = 1
+	}
+	forEachConcurrently(len(chunks), concurrency, func(c int) {
+		chunk := chunks[c]
+		errs := sendWithRetry(ctx, len(chunk), p.maxAttempts, p.backoff, p.logger, func(ctx context.Context, pending []int) []error {
+			batch := make([]types.SendMessageBatchRequestEntry, len(pending))
+			for i, idx := range pending {
+				batch[i] = entries[chunk[idx]]
+				batch[i].Id = aws.String(strconv.Itoa(i))
+			}
+			return p.sendBatch(ctx, batch)
+		})
+		for i, err := range errs {
+			results[positions[chunk[i]]].Err = err
+		}
+	})
+
+	logPublishResults(p.logger, "sqs", results)
+
+	return results
+}
+
+func (p *SQSPublisher) sendBatch(ctx context.Context, batch []types.SendMessageBatchRequestEntry) []error {
+	errs := make([]error, len(batch))
+
+	output, err := p.client.SendMessageBatch(ctx, &sqs.SendMessageBatchInput{
+		QueueUrl: aws.String(p.queueURL),
+		Entries:  batch,
+	})
+	if err != nil {
+		requestErr := domain.NewRetriableError(err, "send message batch failed")
+		for i := range errs {
+			errs[i] = requestErr
+		}
+		return errs
+	}
+
+	for _, failed := range output.Failed {
+		i, convErr := strconv.Atoi(aws.ToString(failed.Id))
+		if convErr != nil || i < 0 || i >= len(errs) {
+			batchErr := unknownBatchEntryError(aws.ToString(failed.Id))
+			for i := range errs {
+				errs[i] = batchErr
+			}
+			return errs
+		}
+		errs[i] = batchEntryError(aws.ToString(failed.Code), aws.ToString(failed.Message), failed.SenderFault)
+	}
+	return errs
+}
+
+func sqsMessageAttributes(event *domain.Event) map[string]types.MessageAttributeValue {
+	attributes := make(map[string]types.MessageAttributeValue)
+	for name, value := range eventAttributes(event) {
+		attributes[name] = types.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(value),
+		}
+	}
+	return attributes
+}
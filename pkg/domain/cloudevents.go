@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	CloudEventsSpecVersion     = "1.0"
+	CloudEventsJSONContentType = "application/json"
+)
+
+type EventFormat string
+
+const (
+	EventFormatEventBridge EventFormat = "eventbridge"
+	EventFormatCloudEvents EventFormat = "cloudevents"
+)
+
+var (
+	ErrUnsupportedEventFormat = errors.New("unsupported event format")
+	ErrInvalidCloudEvent      = errors.New("invalid cloud event")
+)
+
+func ParseEventFormat(value string) (EventFormat, error) {
+	switch format := EventFormat(value); format {
+	case "", EventFormatEventBridge:
+		return EventFormatEventBridge, nil
+	case EventFormatCloudEvents:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedEventFormat, value)
+	}
+}
+
+// Structured-mode CloudEvents JSON; correlationid and aggregateversion are
+// extension attributes.
+type CloudEvent struct {
+	SpecVersion      string          `json:"specversion"`
+	ID               string          `json:"id"`
+	Source           string          `json:"source"`
+	Type             string          `json:"type"`
+	Time             *time.Time      `json:"time,omitempty"`
+	Subject          string          `json:"subject,omitempty"`
+	DataContentType  string          `json:"datacontenttype,omitempty"`
+	DataSchema       string          `json:"dataschema,omitempty"`
+	CorrelationID    string          `json:"correlationid,omitempty"`
+	AggregateVersion int64           `json:"aggregateversion,omitempty"`
+	Data             json.RawMessage `json:"data"`
+}
+
+func (e *Event) ToCloudEvent() *CloudEvent {
+	cloudEvent := &CloudEvent{
+		SpecVersion:      CloudEventsSpecVersion,
+		ID:               e.EventID,
+		Source:           e.Source,
+		Type:             e.EventType,
+		Subject:          string(e.OrderID),
+		DataContentType:  CloudEventsJSONContentType,
+		CorrelationID:    e.CorrelationID,
+		AggregateVersion: e.AggregateVersion,
+		Data:             e.Data,
+	}
+	if !e.CreatedAt.IsZero() {
+		occurredAt := e.CreatedAt.UTC()
+		cloudEvent.Time = &occurredAt
+	}
+	if definition, ok := defaultEventTypes.Lookup(e.Source, e.EventType, e.Version); ok {
+		cloudEvent.DataSchema = definition.SchemaID
+	}
+	return cloudEvent
+}
+
+func (e *Event) Encode(format EventFormat) (json.RawMessage, error) {
+	switch format {
+	case "", EventFormatEventBridge:
+		return e.ToEventBridgeDetail(), nil
+	case EventFormatCloudEvents:
+		return json.Marshal(e.ToCloudEvent())
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedEventFormat, format)
+	}
+}
+
+func EventFromCloudEvent(data []byte) (*Event, error) {
+	var cloudEvent CloudEvent
+	if err := json.Unmarshal(data, &cloudEvent); err != nil {
+		return nil, fmt.Errorf("unmarshal cloud event: %w", err)
+	}
+	if cloudEvent.SpecVersion != CloudEventsSpecVersion {
+		return nil, fmt.Errorf("%w: unsupported specversion %q", ErrInvalidCloudEvent, cloudEvent.SpecVersion)
+	}
+	if cloudEvent.ID == "" || cloudEvent.Source == "" || cloudEvent.Type == "" {
+		return nil, fmt.Errorf("%w: id, source and type are required", ErrInvalidCloudEvent)
+	}
+
+	event, err := EventFromDetail(cloudEvent.Source, cloudEvent.Type, cloudEvent.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	event.EventID = cloudEvent.ID
+	if cloudEvent.CorrelationID != "" {
+		event.CorrelationID = cloudEvent.CorrelationID
+	}
+	if cloudEvent.Subject != "" {
+		event.OrderID = OrderID(cloudEvent.Subject)
+	}
+	if cloudEvent.AggregateVersion != 0 {
+		event.AggregateVersion = cloudEvent.AggregateVersion
+	}
+	if cloudEvent.Time != nil {
+		event.CreatedAt = *cloudEvent.Time
+	}
+	return event, nil
+}
+
+// Accepts either a plain EventBridge detail or a CloudEvents envelope carried
+// in the detail.
+func DecodeEventDetail(source, detailType string, detail json.RawMessage) (*Event, error) {
+	var probe struct {
+		SpecVersion string `json:"specversion"`
+	}
+	if err := json.Unmarshal(detail, &probe); err != nil {
+		return nil, fmt.Errorf("unmarshal event detail: %w", err)
+	}
+	if probe.SpecVersion != "" {
+		return EventFromCloudEvent(detail)
+	}
+	return EventFromDetail(source, detailType, detail)
+}
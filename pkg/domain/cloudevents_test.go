@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCloudEventRoundTrip(t *testing.T) {
+	order, err := NewOrder("order-123", "customer-456", []LineItem{{SKU: "sku-1", Quantity: 2, UnitPrice: Money{Amount: 500, Currency: "EUR"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	event := NewOrderCreatedEvent("event-1", "corr-1", order)
+
+	encoded, err := event.Encode(EventFormatCloudEvents)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var attributes map[string]interface{}
+	if err := json.Unmarshal(encoded, &attributes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]string{
+		"specversion":   CloudEventsSpecVersion,
+		"id":            "event-1",
+		"source":        EventSourceOrders,
+		"type":          EventTypeOrderCreated,
+		"subject":       "order-123",
+		"correlationid": "corr-1",
+		"dataschema":    "https://schemas.app.orders/OrderCreated/2.0.json",
+	}
+	for key, want := range expected {
+		if attributes[key] != want {
+			t.Errorf("expected %s=%q, got %v", key, want, attributes[key])
+		}
+	}
+
+	decoded, err := DecodeEventDetail("ignored", "ignored", encoded)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded.EventID != event.EventID || decoded.CorrelationID != event.CorrelationID || decoded.OrderID != event.OrderID {
+		t.Errorf("unexpected decoded event: %+v", decoded)
+	}
+	if decoded.Source != EventSourceOrders || decoded.EventType != EventTypeOrderCreated || decoded.Version != EventVersionV2 {
+		t.Errorf("unexpected decoded type: %s %s %s", decoded.Source, decoded.EventType, decoded.Version)
+	}
+	if decoded.AggregateVersion != 1 || !decoded.CreatedAt.Equal(event.CreatedAt) {
+		t.Errorf("unexpected decoded aggregate version or time: %d %s", decoded.AggregateVersion, decoded.CreatedAt)
+	}
+	if err := ValidateEvent(decoded); err != nil {
+		t.Errorf("decoded event failed validation: %v", err)
+	}
+}
+
+func TestDecodeEventDetail(t *testing.T) {
+	tests := []struct {
+		name    string
+		detail  string
+		wantID  string
+		wantErr bool
+	}{
+		{name: "eventbridge detail", detail: `{"event_id":"event-1","order_id":"order-123","version":"2.0"}`, wantID: "event-1"},
+		{name: "cloud event", detail: `{"specversion":"1.0","id":"event-2","source":"app.orders","type":"OrderConfirmed","data":{"event_id":"event-2","order_id":"order-123"}}`, wantID: "event-2"},
+		{name: "unsupported specversion", detail: `{"specversion":"0.3","id":"event-3","source":"app.orders","type":"OrderConfirmed","data":{}}`, wantErr: true},
+		{name: "missing type", detail: `{"specversion":"1.0","id":"event-4","source":"app.orders","data":{}}`, wantErr: true},
+		{name: "not json", detail: `{`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := DecodeEventDetail(EventSourceOrders, EventTypeOrderCreated, json.RawMessage(tt.detail))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err == nil && event.EventID != tt.wantID {
+				t.Errorf("expected event id %s, got %s", tt.wantID, event.EventID)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrRetriable             = errors.New("retriable error")
+	ErrNonRetriable          = errors.New("non-retriable error")
+	ErrConcurrencyConflict   = errors.New("concurrency conflict")
+	ErrRequestInProgress     = errors.New("request with this idempotency key is in progress")
+	ErrIdempotencyKeyReuse   = errors.New("idempotency key reused with a different request")
+	ErrEventAlreadyProcessed = errors.New("event already processed")
+	ErrStaleEvent            = errors.New("event is older than the projected state")
+)
+
+type ConcurrencyError struct {
+	OrderID         OrderID
+	ExpectedVersion int64
+}
+
+func (e *ConcurrencyError) Error() string {
+	return fmt.Sprintf("concurrency conflict on order %s: expected version %d", e.OrderID, e.ExpectedVersion)
+}
+
+func (e *ConcurrencyError) Is(target error) bool {
+	return target == ErrConcurrencyConflict
+}
+
+type AppError struct {
+	Err        error
+	Retriable  bool
+	HTTPStatus int
+	Message    string
+}
+
+func (e *AppError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return e.Err.Error()
+}
+
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+func NewValidationError(err error, message string) *AppError {
+	return &AppError{
+		Err:        err,
+		Retriable:  false,
+		HTTPStatus: 400,
+		Message:    message,
+	}
+}
+
+func NewNotFoundError(err error, message string) *AppError {
+	return &AppError{
+		Err:        err,
+		Retriable:  false,
+		HTTPStatus: 404,
+		Message:    message,
+	}
+}
+
+func NewConflictError(err error, message string) *AppError {
+	return &AppError{
+		Err:        err,
+		Retriable:  false,
+		HTTPStatus: 409,
+		Message:    message,
+	}
+}
+
+func NewUnprocessableError(err error, message string) *AppError {
+	return &AppError{
+		Err:        err,
+		Retriable:  false,
+		HTTPStatus: 422,
+		Message:    message,
+	}
+}
+
+func NewRetriableError(err error, message string) *AppError {
+	return &AppError{
+		Err:        err,
+		Retriable:  true,
+		HTTPStatus: 500,
+		Message:    message,
+	}
+}
+
+func NewNonRetriableError(err error, message string) *AppError {
+	return &AppError{
+		Err:        err,
+		Retriable:  false,
+		HTTPStatus: 500,
+		Message:    message,
+	}
+}
+
+func IsRetriable(err error) bool {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.Retriable
+	}
+	return false
+}
+
+func HTTPStatus(err error) int {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.HTTPStatus
+	}
+	return 500
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestConcurrencyError(t *testing.T) {
+	err := fmt.Errorf("save event: %w", &ConcurrencyError{OrderID: "order-123", ExpectedVersion: 2})
+
+	if !errors.Is(err, ErrConcurrencyConflict) {
+		t.Errorf("expected wrapped ConcurrencyError to match ErrConcurrencyConflict")
+	}
+
+	var concurrencyErr *ConcurrencyError
+	if !errors.As(err, &concurrencyErr) {
+		t.Fatalf("expected errors.As to find ConcurrencyError")
+	}
+	if concurrencyErr.ExpectedVersion != 2 {
+		t.Errorf("expected version 2, got %d", concurrencyErr.ExpectedVersion)
+	}
+
+	appErr := NewConflictError(err, "order was modified concurrently")
+	if HTTPStatus(appErr) != 409 {
+		t.Errorf("expected HTTP status 409, got %d", HTTPStatus(appErr))
+	}
+	if IsRetriable(appErr) {
+		t.Errorf("expected conflict error to be non-retriable")
+	}
+}
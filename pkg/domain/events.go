@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	EventTypeOrderCreated   = "OrderCreated"
+	EventTypeOrderConfirmed = "OrderConfirmed"
+	EventTypeOrderCancelled = "OrderCancelled"
+	EventTypeOrderShipped   = "OrderShipped"
+	EventTypeOrderDelivered = "OrderDelivered"
+	EventSourceOrders       = "app.orders"
+	EventVersionV1          = "1.0"
+	EventVersionV2          = "2.0"
+)
+
+type Event struct {
+	EventID          string          `json:"event_id"`
+	CorrelationID    string          `json:"correlation_id"`
+	EventType        string          `json:"event_type"`
+	Source           string          `json:"source"`
+	Version          string          `json:"version"`
+	AggregateVersion int64           `json:"aggregate_version"`
+	OrderID          OrderID         `json:"order_id"`
+	CustomerID       CustomerID      `json:"customer_id"`
+	TotalCents       int64           `json:"total_cents"`
+	CreatedAt        time.Time       `json:"created_at"`
+	Data             json.RawMessage `json:"data,omitempty"`
+}
+
+// OrderCreatedEventV1 covers both 1.0 shapes: the original payload with only a
+// total, and the later one that already carried items and currency.
+type OrderCreatedEventV1 struct {
+	EventID          string          `json:"event_id"`
+	CorrelationID    string          `json:"correlation_id"`
+	OrderID          string          `json:"order_id"`
+	CustomerID       string          `json:"customer_id"`
+	Items            []OrderLineItem `json:"items,omitempty"`
+	Currency         string          `json:"currency,omitempty"`
+	TotalCents       int64           `json:"total_cents"`
+	CreatedAt        string          `json:"created_at"`
+	Version          string          `json:"version"`
+	AggregateVersion int64           `json:"aggregate_version"`
+}
+
+type OrderCreatedEvent struct {
+	EventID          string          `json:"event_id"`
+	CorrelationID    string          `json:"correlation_id"`
+	OrderID          string          `json:"order_id"`
+	CustomerID       string          `json:"customer_id"`
+	Items            []OrderLineItem `json:"items"`
+	Currency         string          `json:"currency"`
+	TotalCents       int64           `json:"total_cents"`
+	CreatedAt        string          `json:"created_at"`
+	Version          string          `json:"version"`
+	AggregateVersion int64           `json:"aggregate_version"`
+}
+
+type OrderLineItem struct {
+	SKU            string `json:"sku"`
+	Quantity       int64  `json:"quantity"`
+	UnitPriceMinor int64  `json:"unit_price_minor"`
+}
+
+func lineItemsToPayload(items []LineItem) []OrderLineItem {
+	payload := make([]OrderLineItem, 0, len(items))
+	for _, item := range items {
+		payload = append(payload, OrderLineItem{
+			SKU:            item.SKU,
+			Quantity:       item.Quantity,
+			UnitPriceMinor: item.UnitPrice.Amount,
+		})
+	}
+	return payload
+}
+
+func lineItemsFromPayload(payload []OrderLineItem, currency Currency) []LineItem {
+	items := make([]LineItem, 0, len(payload))
+	for _, item := range payload {
+		items = append(items, LineItem{
+			SKU:       item.SKU,
+			Quantity:  item.Quantity,
+			UnitPrice: Money{Amount: item.UnitPriceMinor, Currency: currency},
+		})
+	}
+	return items
+}
+
+type OrderStatusChangedEvent struct {
+	EventID          string `json:"event_id"`
+	CorrelationID    string `json:"correlation_id"`
+	OrderID          string `json:"order_id"`
+	Status           string `json:"status"`
+	Reason           string `json:"reason,omitempty"`
+	TrackingNumber   string `json:"tracking_number,omitempty"`
+	OccurredAt       string `json:"occurred_at"`
+	Version          string `json:"version"`
+	AggregateVersion int64  `json:"aggregate_version"`
+}
+
+func NewOrderCreatedEvent(eventID, correlationID string, order *Order) *Event {
+	orderCreated := OrderCreatedEvent{
+		EventID:          eventID,
+		CorrelationID:    correlationID,
+		OrderID:          string(order.ID),
+		CustomerID:       string(order.CustomerID),
+		Items:            lineItemsToPayload(order.Items),
+		Currency:         string(order.Total.Currency),
+		TotalCents:       order.Total.Amount,
+		CreatedAt:        order.CreatedAt.Format(time.RFC3339),
+		Version:          EventVersionV2,
+		AggregateVersion: 1,
+	}
+
+	data, _ := json.Marshal(orderCreated)
+
+	return &Event{
+		EventID:          eventID,
+		CorrelationID:    correlationID,
+		EventType:        EventTypeOrderCreated,
+		Source:           EventSourceOrders,
+		Version:          EventVersionV2,
+		AggregateVersion: 1,
+		OrderID:          order.ID,
+		CustomerID:       order.CustomerID,
+		TotalCents:       order.Total.Amount,
+		CreatedAt:        order.CreatedAt,
+		Data:             data,
+	}
+}
+
+func NewOrderStatusChangedEvent(eventType, eventID, correlationID string, order *Order, status OrderStatus, reason, trackingNumber string, occurredAt time.Time) *Event {
+	statusChanged := OrderStatusChangedEvent{
+		EventID:          eventID,
+		CorrelationID:    correlationID,
+		OrderID:          string(order.ID),
+		Status:           string(status),
+		Reason:           reason,
+		TrackingNumber:   trackingNumber,
+		OccurredAt:       occurredAt.Format(time.RFC3339),
+		Version:          EventVersionV1,
+		AggregateVersion: order.Version + 1,
+	}
+
+	data, _ := json.Marshal(statusChanged)
+
+	return &Event{
+		EventID:          eventID,
+		CorrelationID:    correlationID,
+		EventType:        eventType,
+		Source:           EventSourceOrders,
+		Version:          EventVersionV1,
+		AggregateVersion: order.Version + 1,
+		OrderID:          order.ID,
+		CustomerID:       order.CustomerID,
+		TotalCents:       order.Total.Amount,
+		CreatedAt:        occurredAt,
+		Data:             data,
+	}
+}
+
+func (e *Event) ToEventBridgeDetail() json.RawMessage {
+	return e.Data
+}
+
+type eventDetailEnvelope struct {
+	EventID          string `json:"event_id"`
+	CorrelationID    string `json:"correlation_id"`
+	OrderID          string `json:"order_id"`
+	Version          string `json:"version"`
+	AggregateVersion int64  `json:"aggregate_version"`
+}
+
+func EventFromDetail(source, detailType string, detail json.RawMessage) (*Event, error) {
+	var envelope eventDetailEnvelope
+	if err := json.Unmarshal(detail, &envelope); err != nil {
+		return nil, fmt.Errorf("unmarshal event detail: %w", err)
+	}
+
+	version := envelope.Version
+	if version == "" {
+		version = EventVersionV1
+	}
+
+	return &Event{
+		EventID:          envelope.EventID,
+		CorrelationID:    envelope.CorrelationID,
+		EventType:        detailType,
+		Source:           source,
+		Version:          version,
+		AggregateVersion: envelope.AggregateVersion,
+		OrderID:          OrderID(envelope.OrderID),
+		Data:             detail,
+	}, nil
+}
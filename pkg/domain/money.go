@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	ErrInvalidCurrency  = errors.New("invalid currency: must be an ISO 4217 code")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrAmountOverflow   = errors.New("amount overflow")
+)
+
+type Currency string
+
+const DefaultCurrency Currency = "EUR"
+
+var currencyMinorUnits = map[Currency]int{
+	"AUD": 2,
+	"BGN": 2,
+	"BHD": 3,
+	"BRL": 2,
+	"CAD": 2,
+	"CHF": 2,
+	"CLP": 0,
+	"CNY": 2,
+	"CZK": 2,
+	"DKK": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"HKD": 2,
+	"HUF": 2,
+	"INR": 2,
+	"ISK": 0,
+	"JOD": 3,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"MXN": 2,
+	"NOK": 2,
+	"NZD": 2,
+	"OMR": 3,
+	"PLN": 2,
+	"RON": 2,
+	"SEK": 2,
+	"SGD": 2,
+	"TND": 3,
+	"TRY": 2,
+	"USD": 2,
+	"ZAR": 2,
+}
+
+func ParseCurrency(code string) (Currency, error) {
+	currency := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if _, ok := currencyMinorUnits[currency]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+	}
+	return currency, nil
+}
+
+func (c Currency) MinorUnits() int {
+	return currencyMinorUnits[c]
+}
+
+type Money struct {
+	Amount   int64    `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+func NewMoney(amount int64, currency Currency) (Money, error) {
+	if _, ok := currencyMinorUnits[currency]; !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	if (other.Amount > 0 && m.Amount > math.MaxInt64-other.Amount) || (other.Amount < 0 && m.Amount < math.MinInt64-other.Amount) {
+		return Money{}, ErrAmountOverflow
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+func (m Money) Multiply(factor int64) (Money, error) {
+	if factor != 0 && (m.Amount*factor)/factor != m.Amount {
+		return Money{}, ErrAmountOverflow
+	}
+	return Money{Amount: m.Amount * factor, Currency: m.Currency}, nil
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+func (m Money) String() string {
+	units := m.Currency.MinorUnits()
+	if units == 0 {
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+	}
+
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	divisor := int64(math.Pow10(units))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/divisor, units, amount%divisor, m.Currency)
+}
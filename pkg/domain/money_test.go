@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCurrency(t *testing.T) {
+	tests := []struct {
+		code        string
+		expected    Currency
+		minorUnits  int
+		expectError bool
+	}{
+		{code: "EUR", expected: "EUR", minorUnits: 2},
+		{code: "jpy", expected: "JPY", minorUnits: 0},
+		{code: "KWD", expected: "KWD", minorUnits: 3},
+		{code: "", expectError: true},
+		{code: "EURO", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			currency, err := ParseCurrency(tt.code)
+			if tt.expectError {
+				if !errors.Is(err, ErrInvalidCurrency) {
+					t.Errorf("expected ErrInvalidCurrency, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if currency != tt.expected {
+				t.Errorf("expected currency %s, got %s", tt.expected, currency)
+			}
+			if currency.MinorUnits() != tt.minorUnits {
+				t.Errorf("expected %d minor units, got %d", tt.minorUnits, currency.MinorUnits())
+			}
+		})
+	}
+}
+
+func TestMoney(t *testing.T) {
+	price := Money{Amount: 1299, Currency: "EUR"}
+
+	total, err := price.Multiply(3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total.String() != "38.97 EUR" {
+		t.Errorf("expected 38.97 EUR, got %s", total)
+	}
+
+	if _, err := price.Add(Money{Amount: 100, Currency: "USD"}); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+	}
+
+	if _, err := (Money{Amount: 1 << 62, Currency: "EUR"}).Multiply(4); !errors.Is(err, ErrAmountOverflow) {
+		t.Errorf("expected ErrAmountOverflow, got %v", err)
+	}
+
+	if s := (Money{Amount: 500, Currency: "JPY"}).String(); s != "500 JPY" {
+		t.Errorf("expected 500 JPY, got %s", s)
+	}
+}
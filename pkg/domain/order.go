@@ -0,0 +1,243 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidOrderID          = errors.New("invalid order id")
+	ErrInvalidCustomerID       = errors.New("invalid customer id")
+	ErrInvalidTotal            = errors.New("invalid total: must be greater than 0")
+	ErrNoLineItems             = errors.New("order must contain at least one line item")
+	ErrInvalidSKU              = errors.New("invalid sku")
+	ErrInvalidQuantity         = errors.New("invalid quantity: must be greater than 0")
+	ErrInvalidUnitPrice        = errors.New("invalid unit price: must not be negative")
+	ErrOrderAlreadyExists      = errors.New("order already exists")
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrEventOutOfSequence      = errors.New("event out of sequence")
+	ErrUnknownEventType        = errors.New("unknown event type")
+)
+
+type OrderID string
+type CustomerID string
+
+type OrderStatus string
+
+const (
+	OrderStatusCreated   OrderStatus = "CREATED"
+	OrderStatusConfirmed OrderStatus = "CONFIRMED"
+	OrderStatusCancelled OrderStatus = "CANCELLED"
+	OrderStatusShipped   OrderStatus = "SHIPPED"
+	OrderStatusDelivered OrderStatus = "DELIVERED"
+)
+
+var orderStatusTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusCreated:   {OrderStatusConfirmed, OrderStatusCancelled},
+	OrderStatusConfirmed: {OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusShipped:   {OrderStatusDelivered},
+}
+
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+type LineItem struct {
+	SKU       string
+	Quantity  int64
+	UnitPrice Money
+}
+
+func (li LineItem) Total() (Money, error) {
+	return li.UnitPrice.Multiply(li.Quantity)
+}
+
+type Order struct {
+	ID         OrderID
+	CustomerID CustomerID
+	Items      []LineItem
+	Total      Money
+	Status     OrderStatus
+	Version    int64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func NewOrder(id OrderID, customerID CustomerID, items []LineItem) (*Order, error) {
+	if err := ValidateOrderID(id); err != nil {
+		return nil, err
+	}
+	if err := ValidateCustomerID(customerID); err != nil {
+		return nil, err
+	}
+	total, err := CalculateTotal(items)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateTotal(total); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	return &Order{
+		ID:         id,
+		CustomerID: customerID,
+		Items:      append([]LineItem(nil), items...),
+		Total:      total,
+		Status:     OrderStatusCreated,
+		Version:    1,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+func (o *Order) Confirm(eventID, correlationID string) (*Event, error) {
+	return o.changeStatus(EventTypeOrderConfirmed, OrderStatusConfirmed, eventID, correlationID, "", "")
+}
+
+func (o *Order) Cancel(eventID, correlationID, reason string) (*Event, error) {
+	return o.changeStatus(EventTypeOrderCancelled, OrderStatusCancelled, eventID, correlationID, reason, "")
+}
+
+func (o *Order) Ship(eventID, correlationID, trackingNumber string) (*Event, error) {
+	return o.changeStatus(EventTypeOrderShipped, OrderStatusShipped, eventID, correlationID, "", trackingNumber)
+}
+
+func (o *Order) Deliver(eventID, correlationID string) (*Event, error) {
+	return o.changeStatus(EventTypeOrderDelivered, OrderStatusDelivered, eventID, correlationID, "", "")
+}
+
+func (o *Order) changeStatus(eventType string, next OrderStatus, eventID, correlationID, reason, trackingNumber string) (*Event, error) {
+	if !o.Status.CanTransitionTo(next) {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, o.Status, next)
+	}
+
+	event := NewOrderStatusChangedEvent(eventType, eventID, correlationID, o, next, reason, trackingNumber, time.Now().UTC())
+	if err := o.Apply(event); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func LoadFromHistory(events []*Event) (*Order, error) {
+	if len(events) == 0 {
+		return nil, ErrOrderNotFound
+	}
+
+	order := &Order{}
+	for _, event := range events {
+		if err := order.Apply(event); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+func (o *Order) Apply(event *Event) error {
+	if event.AggregateVersion != o.Version+1 {
+		return fmt.Errorf("%w: order %s at version %d cannot apply version %d", ErrEventOutOfSequence, o.ID, o.Version, event.AggregateVersion)
+	}
+
+	switch event.EventType {
+	case EventTypeOrderCreated:
+		var detail OrderCreatedEvent
+		if err := json.Unmarshal(event.Data, &detail); err != nil {
+			return fmt.Errorf("unmarshal %s: %w", event.EventType, err)
+		}
+		createdAt, err := time.Parse(time.RFC3339, detail.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("parse %s created_at: %w", event.EventType, err)
+		}
+		currency := Currency(detail.Currency)
+		o.ID = OrderID(detail.OrderID)
+		o.CustomerID = CustomerID(detail.CustomerID)
+		o.Items = lineItemsFromPayload(detail.Items, currency)
+		o.Total = Money{Amount: detail.TotalCents, Currency: currency}
+		o.Status = OrderStatusCreated
+		o.CreatedAt = createdAt
+		o.UpdatedAt = createdAt
+	case EventTypeOrderConfirmed, EventTypeOrderCancelled, EventTypeOrderShipped, EventTypeOrderDelivered:
+		var detail OrderStatusChangedEvent
+		if err := json.Unmarshal(event.Data, &detail); err != nil {
+			return fmt.Errorf("unmarshal %s: %w", event.EventType, err)
+		}
+		occurredAt, err := time.Parse(time.RFC3339, detail.OccurredAt)
+		if err != nil {
+			return fmt.Errorf("parse %s occurred_at: %w", event.EventType, err)
+		}
+		o.Status = OrderStatus(detail.Status)
+		o.UpdatedAt = occurredAt
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownEventType, event.EventType)
+	}
+
+	o.Version = event.AggregateVersion
+	return nil
+}
+
+func ValidateOrderID(id OrderID) error {
+	if id == "" {
+		return ErrInvalidOrderID
+	}
+	return nil
+}
+
+func ValidateCustomerID(customerID CustomerID) error {
+	if customerID == "" {
+		return ErrInvalidCustomerID
+	}
+	return nil
+}
+
+func ValidateLineItem(item LineItem) error {
+	if item.SKU == "" {
+		return ErrInvalidSKU
+	}
+	if item.Quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	if item.UnitPrice.Amount < 0 {
+		return ErrInvalidUnitPrice
+	}
+	if _, err := NewMoney(item.UnitPrice.Amount, item.UnitPrice.Currency); err != nil {
+		return err
+	}
+	return nil
+}
+
+func CalculateTotal(items []LineItem) (Money, error) {
+	if len(items) == 0 {
+		return Money{}, ErrNoLineItems
+	}
+
+	total := Money{Currency: items[0].UnitPrice.Currency}
+	for _, item := range items {
+		if err := ValidateLineItem(item); err != nil {
+			return Money{}, err
+		}
+		itemTotal, err := item.Total()
+		if err != nil {
+			return Money{}, err
+		}
+		if total, err = total.Add(itemTotal); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+func ValidateTotal(total Money) error {
+	if !total.IsPositive() {
+		return ErrInvalidTotal
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewOrder(t *testing.T) {
+	eur := func(amount int64) Money { return Money{Amount: amount, Currency: "EUR"} }
+
+	tests := []struct {
+		name          string
+		orderID       OrderID
+		customerID    CustomerID
+		items         []LineItem
+		expectError   bool
+		errorType     error
+		expectedTotal Money
+	}{
+		{
+			name:          "valid order",
+			orderID:       "order-123",
+			customerID:    "customer-456",
+			items:         []LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: eur(10000)}},
+			expectError:   false,
+			expectedTotal: eur(10000),
+		},
+		{
+			name:       "total derived from items",
+			orderID:    "order-123",
+			customerID: "customer-456",
+			items: []LineItem{
+				{SKU: "sku-1", Quantity: 2, UnitPrice: eur(1299)},
+				{SKU: "sku-2", Quantity: 3, UnitPrice: eur(500)},
+			},
+			expectError:   false,
+			expectedTotal: eur(4098),
+		},
+		{
+			name:        "empty order id",
+			orderID:     "",
+			customerID:  "customer-456",
+			items:       []LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: eur(10000)}},
+			expectError: true,
+			errorType:   ErrInvalidOrderID,
+		},
+		{
+			name:        "empty customer id",
+			orderID:     "order-123",
+			customerID:  "",
+			items:       []LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: eur(10000)}},
+			expectError: true,
+			errorType:   ErrInvalidCustomerID,
+		},
+		{
+			name:        "no line items",
+			orderID:     "order-123",
+			customerID:  "customer-456",
+			expectError: true,
+			errorType:   ErrNoLineItems,
+		},
+		{
+			name:        "zero total",
+			orderID:     "order-123",
+			customerID:  "customer-456",
+			items:       []LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: eur(0)}},
+			expectError: true,
+			errorType:   ErrInvalidTotal,
+		},
+		{
+			name:        "negative unit price",
+			orderID:     "order-123",
+			customerID:  "customer-456",
+			items:       []LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: eur(-100)}},
+			expectError: true,
+			errorType:   ErrInvalidUnitPrice,
+		},
+		{
+			name:        "zero quantity",
+			orderID:     "order-123",
+			customerID:  "customer-456",
+			items:       []LineItem{{SKU: "sku-1", Quantity: 0, UnitPrice: eur(100)}},
+			expectError: true,
+			errorType:   ErrInvalidQuantity,
+		},
+		{
+			name:        "empty sku",
+			orderID:     "order-123",
+			customerID:  "customer-456",
+			items:       []LineItem{{SKU: "", Quantity: 1, UnitPrice: eur(100)}},
+			expectError: true,
+			errorType:   ErrInvalidSKU,
+		},
+		{
+			name:       "mixed currencies",
+			orderID:    "order-123",
+			customerID: "customer-456",
+			items: []LineItem{
+				{SKU: "sku-1", Quantity: 1, UnitPrice: eur(100)},
+				{SKU: "sku-2", Quantity: 1, UnitPrice: Money{Amount: 100, Currency: "USD"}},
+			},
+			expectError: true,
+			errorType:   ErrCurrencyMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := NewOrder(tt.orderID, tt.customerID, tt.items)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+					return
+				}
+				if !errors.Is(err, tt.errorType) {
+					t.Errorf("expected error %v, got %v", tt.errorType, err)
+				}
+				if order != nil {
+					t.Errorf("expected nil order, got %v", order)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				if order == nil {
+					t.Errorf("expected order but got nil")
+					return
+				}
+				if order.ID != tt.orderID {
+					t.Errorf("expected order ID %s, got %s", tt.orderID, order.ID)
+				}
+				if order.CustomerID != tt.customerID {
+					t.Errorf("expected customer ID %s, got %s", tt.customerID, order.CustomerID)
+				}
+				if order.Total != tt.expectedTotal {
+					t.Errorf("expected total %s, got %s", tt.expectedTotal, order.Total)
+				}
+			}
+		})
+	}
+}
+
+func TestOrderStatusTransitions(t *testing.T) {
+	tests := []struct {
+		name       string
+		from       OrderStatus
+		transition func(o *Order) (*Event, error)
+		expected   OrderStatus
+		eventType  string
+		expectErr  bool
+	}{
+		{
+			name:       "confirm created order",
+			from:       OrderStatusCreated,
+			transition: func(o *Order) (*Event, error) { return o.Confirm("event-1", "corr-1") },
+			expected:   OrderStatusConfirmed,
+			eventType:  EventTypeOrderConfirmed,
+		},
+		{
+			name:       "cancel created order",
+			from:       OrderStatusCreated,
+			transition: func(o *Order) (*Event, error) { return o.Cancel("event-1", "corr-1", "customer request") },
+			expected:   OrderStatusCancelled,
+			eventType:  EventTypeOrderCancelled,
+		},
+		{
+			name:       "ship confirmed order",
+			from:       OrderStatusConfirmed,
+			transition: func(o *Order) (*Event, error) { return o.Ship("event-1", "corr-1", "TRACK-1") },
+			expected:   OrderStatusShipped,
+			eventType:  EventTypeOrderShipped,
+		},
+		{
+			name:       "cancel confirmed order",
+			from:       OrderStatusConfirmed,
+			transition: func(o *Order) (*Event, error) { return o.Cancel("event-1", "corr-1", "") },
+			expected:   OrderStatusCancelled,
+			eventType:  EventTypeOrderCancelled,
+		},
+		{
+			name:       "deliver shipped order",
+			from:       OrderStatusShipped,
+			transition: func(o *Order) (*Event, error) { return o.Deliver("event-1", "corr-1") },
+			expected:   OrderStatusDelivered,
+			eventType:  EventTypeOrderDelivered,
+		},
+		{
+			name:       "ship created order",
+			from:       OrderStatusCreated,
+			transition: func(o *Order) (*Event, error) { return o.Ship("event-1", "corr-1", "TRACK-1") },
+			expectErr:  true,
+		},
+		{
+			name:       "cancel shipped order",
+			from:       OrderStatusShipped,
+			transition: func(o *Order) (*Event, error) { return o.Cancel("event-1", "corr-1", "") },
+			expectErr:  true,
+		},
+		{
+			name:       "confirm cancelled order",
+			from:       OrderStatusCancelled,
+			transition: func(o *Order) (*Event, error) { return o.Confirm("event-1", "corr-1") },
+			expectErr:  true,
+		},
+		{
+			name:       "deliver delivered order",
+			from:       OrderStatusDelivered,
+			transition: func(o *Order) (*Event, error) { return o.Deliver("event-1", "corr-1") },
+			expectErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &Order{ID: "order-123", CustomerID: "customer-456", Total: Money{Amount: 10000, Currency: "EUR"}, Status: tt.from, Version: 3}
+
+			event, err := tt.transition(order)
+			if tt.expectErr {
+				if !errors.Is(err, ErrInvalidStatusTransition) {
+					t.Errorf("expected ErrInvalidStatusTransition, got %v", err)
+				}
+				if order.Status != tt.from || order.Version != 3 {
+					t.Errorf("rejected transition must not change order, got status %s version %d", order.Status, order.Version)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if order.Status != tt.expected {
+				t.Errorf("expected status %s, got %s", tt.expected, order.Status)
+			}
+			if event.EventType != tt.eventType {
+				t.Errorf("expected event type %s, got %s", tt.eventType, event.EventType)
+			}
+			if event.AggregateVersion != 4 || order.Version != 4 {
+				t.Errorf("expected version 4, got event %d order %d", event.AggregateVersion, order.Version)
+			}
+		})
+	}
+}
+
+func TestLoadFromHistory(t *testing.T) {
+	order, err := NewOrder("order-123", "customer-456", []LineItem{{SKU: "sku-1", Quantity: 2, UnitPrice: Money{Amount: 5000, Currency: "EUR"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	history := []*Event{NewOrderCreatedEvent("event-1", "corr-1", order)}
+	for _, transition := range []func(o *Order) (*Event, error){
+		func(o *Order) (*Event, error) { return o.Confirm("event-2", "corr-1") },
+		func(o *Order) (*Event, error) { return o.Ship("event-3", "corr-1", "TRACK-1") },
+	} {
+		event, err := transition(order)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		history = append(history, event)
+	}
+
+	loaded, err := LoadFromHistory(history)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.ID != order.ID || loaded.CustomerID != order.CustomerID || loaded.Total != order.Total || len(loaded.Items) != 1 {
+		t.Errorf("expected order %+v, got %+v", order, loaded)
+	}
+	if loaded.Status != OrderStatusShipped {
+		t.Errorf("expected status %s, got %s", OrderStatusShipped, loaded.Status)
+	}
+	if loaded.Version != 3 {
+		t.Errorf("expected version 3, got %d", loaded.Version)
+	}
+
+	if _, err := LoadFromHistory(nil); !errors.Is(err, ErrOrderNotFound) {
+		t.Errorf("expected ErrOrderNotFound for empty history, got %v", err)
+	}
+
+	if _, err := LoadFromHistory([]*Event{history[0], history[2]}); !errors.Is(err, ErrEventOutOfSequence) {
+		t.Errorf("expected ErrEventOutOfSequence for gap in history, got %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+var (
+	ErrUnregisteredEventType = errors.New("unregistered event type")
+	ErrSchemaViolation       = errors.New("event payload violates schema")
+)
+
+type EventTypeKey struct {
+	Source    string
+	EventType string
+	Version   string
+}
+
+func (k EventTypeKey) String() string {
+	return fmt.Sprintf("%s/%s/%s", k.Source, k.EventType, k.Version)
+}
+
+type EventTypeDefinition struct {
+	Key        EventTypeKey
+	SchemaID   string
+	newPayload func() interface{}
+	schema     *jsonschema.Schema
+}
+
+func (d *EventTypeDefinition) NewPayload() interface{} {
+	return d.newPayload()
+}
+
+type EventTypeRegistry struct {
+	types map[EventTypeKey]*EventTypeDefinition
+}
+
+func NewEventTypeRegistry() *EventTypeRegistry {
+	return &EventTypeRegistry{
+		types: make(map[EventTypeKey]*EventTypeDefinition),
+	}
+}
+
+func NewDefaultEventTypeRegistry() (*EventTypeRegistry, error) {
+	registry := NewEventTypeRegistry()
+
+	registrations := []struct {
+		eventType  string
+		version    string
+		schemaFile string
+		newPayload func() interface{}
+	}{
+		{EventTypeOrderCreated, EventVersionV1, "schemas/order_created.v1.json", func() interface{} { return &OrderCreatedEventV1{} }},
+		{EventTypeOrderCreated, EventVersionV2, "schemas/order_created.v2.json", func() interface{} { return &OrderCreatedEvent{} }},
+		{EventTypeOrderConfirmed, EventVersionV1, "schemas/order_status_changed.v1.json", func() interface{} { return &OrderStatusChangedEvent{} }},
+		{EventTypeOrderCancelled, EventVersionV1, "schemas/order_status_changed.v1.json", func() interface{} { return &OrderStatusChangedEvent{} }},
+		{EventTypeOrderShipped, EventVersionV1, "schemas/order_status_changed.v1.json", func() interface{} { return &OrderStatusChangedEvent{} }},
+		{EventTypeOrderDelivered, EventVersionV1, "schemas/order_status_changed.v1.json", func() interface{} { return &OrderStatusChangedEvent{} }},
+	}
+
+	for _, reg := range registrations {
+		schemaJSON, err := schemaFiles.ReadFile(reg.schemaFile)
+		if err != nil {
+			return nil, fmt.Errorf("read schema %s: %w", reg.schemaFile, err)
+		}
+		if err := registry.Register(EventSourceOrders, reg.eventType, reg.version, reg.newPayload, schemaJSON); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+var defaultEventTypes = mustDefaultEventTypeRegistry()
+
+func mustDefaultEventTypeRegistry() *EventTypeRegistry {
+	registry, err := NewDefaultEventTypeRegistry()
+	if err != nil {
+		panic(fmt.Sprintf("failed to build event type registry: %v", err))
+	}
+	return registry
+}
+
+func DefaultEventTypes() *EventTypeRegistry {
+	return defaultEventTypes
+}
+
+func ValidateEvent(event *Event) error {
+	return defaultEventTypes.Validate(event)
+}
+
+func (r *EventTypeRegistry) Register(source, eventType, version string, newPayload func() interface{}, schemaJSON []byte) error {
+	key := EventTypeKey{Source: source, EventType: eventType, Version: version}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	compiler.AssertFormat = true
+
+	url := "mem://" + key.String() + ".json"
+	if err := compiler.AddResource(url, bytes.NewReader(schemaJSON)); err != nil {
+		return fmt.Errorf("add schema for %s: %w", key, err)
+	}
+	schema, err := compiler.Compile(url)
+	if err != nil {
+		return fmt.Errorf("compile schema for %s: %w", key, err)
+	}
+
+	var header struct {
+		ID string `json:"$id"`
+	}
+	if err := json.Unmarshal(schemaJSON, &header); err != nil {
+		return fmt.Errorf("read schema id for %s: %w", key, err)
+	}
+
+	r.types[key] = &EventTypeDefinition{
+		Key:        key,
+		SchemaID:   header.ID,
+		newPayload: newPayload,
+		schema:     schema,
+	}
+	return nil
+}
+
+func (r *EventTypeRegistry) Lookup(source, eventType, version string) (*EventTypeDefinition, bool) {
+	definition, ok := r.types[EventTypeKey{Source: source, EventType: eventType, Version: version}]
+	return definition, ok
+}
+
+func (r *EventTypeRegistry) Validate(event *Event) error {
+	_, err := r.validate(event)
+	return err
+}
+
+func (r *EventTypeRegistry) Decode(event *Event) (interface{}, error) {
+	definition, err := r.validate(event)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := definition.NewPayload()
+	if err := json.Unmarshal(event.Data, payload); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrSchemaViolation, definition.Key, err)
+	}
+	return payload, nil
+}
+
+func (r *EventTypeRegistry) validate(event *Event) (*EventTypeDefinition, error) {
+	definition, ok := r.Lookup(event.Source, event.EventType, event.Version)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnregisteredEventType, EventTypeKey{Source: event.Source, EventType: event.EventType, Version: event.Version})
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(event.Data, &document); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrSchemaViolation, definition.Key, err)
+	}
+	if err := definition.schema.Validate(document); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrSchemaViolation, definition.Key, err)
+	}
+	return definition, nil
+}
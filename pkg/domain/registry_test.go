@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestEventTypeRegistryValidate(t *testing.T) {
+	order, err := NewOrder("order-123", "customer-456", []LineItem{{SKU: "sku-1", Quantity: 2, UnitPrice: Money{Amount: 500, Currency: "EUR"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created := NewOrderCreatedEvent("event-1", "corr-1", order)
+	confirmed, err := order.Confirm("event-2", "corr-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	legacy, err := EventFromDetail(EventSourceOrders, EventTypeOrderCreated, []byte(`{"event_id":"event-1","order_id":"order-123","customer_id":"customer-456","total_cents":10000,"created_at":"2024-01-02T03:04:05Z"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	withData := func(event *Event, data string) *Event {
+		copied := *event
+		copied.Data = json.RawMessage(data)
+		return &copied
+	}
+	withVersion := func(event *Event, version string) *Event {
+		copied := *event
+		copied.Version = version
+		return &copied
+	}
+
+	tests := []struct {
+		name    string
+		event   *Event
+		wantErr error
+	}{
+		{name: "order created v2", event: created},
+		{name: "order confirmed", event: confirmed},
+		{name: "legacy order created v1", event: legacy},
+		{name: "missing line items", event: withData(created, `{"event_id":"event-1","order_id":"order-123","customer_id":"customer-456","currency":"EUR","total_cents":1000,"created_at":"2024-01-02T03:04:05Z","version":"2.0"}`), wantErr: ErrSchemaViolation},
+		{name: "invalid timestamp", event: withData(legacy, `{"event_id":"event-1","order_id":"order-123","customer_id":"customer-456","total_cents":10000,"created_at":"yesterday"}`), wantErr: ErrSchemaViolation},
+		{name: "unknown status", event: withData(confirmed, `{"event_id":"event-2","order_id":"order-123","status":"LOST","occurred_at":"2024-01-02T03:04:05Z","version":"1.0"}`), wantErr: ErrSchemaViolation},
+		{name: "not json", event: withData(created, `{`), wantErr: ErrSchemaViolation},
+		{name: "unregistered version", event: withVersion(created, "3.0"), wantErr: ErrUnregisteredEventType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEvent(tt.event)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestEventTypeRegistryDecode(t *testing.T) {
+	order, err := NewOrder("order-123", "customer-456", []LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: Money{Amount: 100, Currency: "USD"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	payload, err := DefaultEventTypes().Decode(NewOrderCreatedEvent("event-1", "corr-1", order))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	detail, ok := payload.(*OrderCreatedEvent)
+	if !ok {
+		t.Fatalf("expected *OrderCreatedEvent, got %T", payload)
+	}
+	if detail.Currency != "USD" || len(detail.Items) != 1 {
+		t.Errorf("unexpected payload: %+v", detail)
+	}
+}
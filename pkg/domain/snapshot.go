@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const OrderSnapshotSchemaVersion = 2
+
+var ErrSnapshotSchemaMismatch = errors.New("snapshot schema version mismatch")
+
+type OrderSnapshot struct {
+	OrderID       OrderID
+	Version       int64
+	SchemaVersion int
+	State         json.RawMessage
+	CreatedAt     time.Time
+}
+
+type orderSnapshotState struct {
+	ID         string          `json:"id"`
+	CustomerID string          `json:"customer_id"`
+	Items      []OrderLineItem `json:"items"`
+	Total      Money           `json:"total"`
+	Status     string          `json:"status"`
+	Version    int64           `json:"version"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+func NewOrderSnapshot(order *Order) (*OrderSnapshot, error) {
+	state, err := json.Marshal(orderSnapshotState{
+		ID:         string(order.ID),
+		CustomerID: string(order.CustomerID),
+		Items:      lineItemsToPayload(order.Items),
+		Total:      order.Total,
+		Status:     string(order.Status),
+		Version:    order.Version,
+		CreatedAt:  order.CreatedAt,
+		UpdatedAt:  order.UpdatedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal snapshot state: %w", err)
+	}
+
+	return &OrderSnapshot{
+		OrderID:       order.ID,
+		Version:       order.Version,
+		SchemaVersion: OrderSnapshotSchemaVersion,
+		State:         state,
+		CreatedAt:     time.Now().UTC(),
+	}, nil
+}
+
+func (s *OrderSnapshot) Restore() (*Order, error) {
+	if s.SchemaVersion != OrderSnapshotSchemaVersion {
+		return nil, fmt.Errorf("%w: got %d, want %d", ErrSnapshotSchemaMismatch, s.SchemaVersion, OrderSnapshotSchemaVersion)
+	}
+
+	var state orderSnapshotState
+	if err := json.Unmarshal(s.State, &state); err != nil {
+		return nil, fmt.Errorf("unmarshal snapshot state: %w", err)
+	}
+
+	return &Order{
+		ID:         OrderID(state.ID),
+		CustomerID: CustomerID(state.CustomerID),
+		Items:      lineItemsFromPayload(state.Items, state.Total.Currency),
+		Total:      state.Total,
+		Status:     OrderStatus(state.Status),
+		Version:    state.Version,
+		CreatedAt:  state.CreatedAt,
+		UpdatedAt:  state.UpdatedAt,
+	}, nil
+}
+
+func LoadFromSnapshot(snapshot *OrderSnapshot, events []*Event) (*Order, error) {
+	order, err := snapshot.Restore()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		if err := order.Apply(event); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOrderSnapshot(t *testing.T) {
+	order, err := NewOrder("order-123", "customer-456", []LineItem{{SKU: "sku-1", Quantity: 2, UnitPrice: Money{Amount: 5000, Currency: "EUR"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created := NewOrderCreatedEvent("event-1", "corr-1", order)
+	confirmed, err := order.Confirm("event-2", "corr-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	snapshot, err := NewOrderSnapshot(order)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if snapshot.Version != 2 {
+		t.Errorf("expected snapshot version 2, got %d", snapshot.Version)
+	}
+
+	shipped, err := order.Ship("event-3", "corr-1", "TRACK-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fromSnapshot, err := LoadFromSnapshot(snapshot, []*Event{shipped})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fromHistory, err := LoadFromHistory([]*Event{created, confirmed, shipped})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fromSnapshot.Status != fromHistory.Status || fromSnapshot.Version != fromHistory.Version || fromSnapshot.Total != fromHistory.Total {
+		t.Errorf("expected snapshot load %+v to match full replay %+v", fromSnapshot, fromHistory)
+	}
+
+	snapshot.SchemaVersion = OrderSnapshotSchemaVersion + 1
+	if _, err := snapshot.Restore(); !errors.Is(err, ErrSnapshotSchemaMismatch) {
+		t.Errorf("expected ErrSnapshotSchemaMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const LegacyLineItemSKU = "LEGACY-ORDER-TOTAL"
+
+var ErrUpcastLoop = errors.New("upcaster chain does not terminate")
+
+type Upcaster func(event *Event) (*Event, error)
+
+type upcasterKey struct {
+	eventType string
+	version   string
+}
+
+type UpcasterRegistry struct {
+	upcasters map[upcasterKey]Upcaster
+}
+
+func NewUpcasterRegistry() *UpcasterRegistry {
+	return &UpcasterRegistry{
+		upcasters: make(map[upcasterKey]Upcaster),
+	}
+}
+
+func NewDefaultUpcasterRegistry() *UpcasterRegistry {
+	registry := NewUpcasterRegistry()
+	registry.Register(EventTypeOrderCreated, EventVersionV1, upcastOrderCreatedV1)
+	return registry
+}
+
+var defaultUpcasters = NewDefaultUpcasterRegistry()
+
+func UpcastEvent(event *Event) (*Event, error) {
+	return defaultUpcasters.Upcast(event)
+}
+
+func (r *UpcasterRegistry) Register(eventType, fromVersion string, upcaster Upcaster) {
+	r.upcasters[upcasterKey{eventType: eventType, version: fromVersion}] = upcaster
+}
+
+func (r *UpcasterRegistry) Upcast(event *Event) (*Event, error) {
+	seen := make(map[string]bool)
+	for {
+		upcaster, ok := r.upcasters[upcasterKey{eventType: event.EventType, version: event.Version}]
+		if !ok {
+			return event, nil
+		}
+		if seen[event.Version] {
+			return nil, fmt.Errorf("%w: %s %s", ErrUpcastLoop, event.EventType, event.Version)
+		}
+		seen[event.Version] = true
+
+		upcasted, err := upcaster(event)
+		if err != nil {
+			return nil, fmt.Errorf("upcast %s %s: %w", event.EventType, event.Version, err)
+		}
+		event = upcasted
+	}
+}
+
+func upcastOrderCreatedV1(event *Event) (*Event, error) {
+	var v1 OrderCreatedEventV1
+	if err := json.Unmarshal(event.Data, &v1); err != nil {
+		return nil, err
+	}
+
+	items := v1.Items
+	if len(items) == 0 {
+		items = []OrderLineItem{
+			{SKU: LegacyLineItemSKU, Quantity: 1, UnitPriceMinor: v1.TotalCents},
+		}
+	}
+	currency := v1.Currency
+	if currency == "" {
+		currency = string(DefaultCurrency)
+	}
+
+	v2 := OrderCreatedEvent{
+		EventID:          v1.EventID,
+		CorrelationID:    v1.CorrelationID,
+		OrderID:          v1.OrderID,
+		CustomerID:       v1.CustomerID,
+		Items:            items,
+		Currency:         currency,
+		TotalCents:       v1.TotalCents,
+		CreatedAt:        v1.CreatedAt,
+		Version:          EventVersionV2,
+		AggregateVersion: v1.AggregateVersion,
+	}
+	if v2.AggregateVersion == 0 {
+		v2.AggregateVersion = event.AggregateVersion
+	}
+
+	data, err := json.Marshal(v2)
+	if err != nil {
+		return nil, err
+	}
+
+	upcasted := *event
+	upcasted.Version = EventVersionV2
+	upcasted.Data = data
+	return &upcasted, nil
+}
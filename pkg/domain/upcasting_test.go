@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestUpcastOrderCreatedV1(t *testing.T) {
+	v1 := []byte(`{"event_id":"event-1","correlation_id":"corr-1","order_id":"order-123","customer_id":"customer-456","total_cents":10000,"created_at":"2024-01-02T03:04:05Z","version":"1.0"}`)
+
+	event, err := EventFromDetail(EventSourceOrders, EventTypeOrderCreated, v1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	event.AggregateVersion = 1
+
+	upcasted, err := UpcastEvent(event)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if upcasted.Version != EventVersionV2 {
+		t.Errorf("expected version %s, got %s", EventVersionV2, upcasted.Version)
+	}
+	if event.Version != EventVersionV1 {
+		t.Errorf("upcasting must not mutate the original event")
+	}
+
+	var detail OrderCreatedEvent
+	if err := json.Unmarshal(upcasted.Data, &detail); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if detail.Currency != string(DefaultCurrency) {
+		t.Errorf("expected currency %s, got %s", DefaultCurrency, detail.Currency)
+	}
+	if len(detail.Items) != 1 || detail.Items[0].UnitPriceMinor != 10000 {
+		t.Errorf("expected a single legacy line item carrying the total, got %+v", detail.Items)
+	}
+	if detail.AggregateVersion != 1 {
+		t.Errorf("expected aggregate version 1, got %d", detail.AggregateVersion)
+	}
+
+	order, err := LoadFromHistory([]*Event{upcasted})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.Total != (Money{Amount: 10000, Currency: DefaultCurrency}) {
+		t.Errorf("expected total 100.00 EUR, got %s", order.Total)
+	}
+}
+
+func TestUpcastOrderCreatedV1WithItems(t *testing.T) {
+	// Shape written by NewOrderCreatedEvent before the payload moved to 2.0.
+	v1 := []byte(`{"event_id":"event-1","correlation_id":"corr-1","order_id":"order-123","customer_id":"customer-456","items":[{"sku":"sku-1","quantity":2,"unit_price_minor":1250},{"sku":"sku-2","quantity":1,"unit_price_minor":500}],"currency":"USD","total_cents":3000,"created_at":"2024-01-02T03:04:05Z","version":"1.0","aggregate_version":1}`)
+
+	event, err := EventFromDetail(EventSourceOrders, EventTypeOrderCreated, v1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateEvent(event); err != nil {
+		t.Fatalf("expected the payload to satisfy the 1.0 schema, got %v", err)
+	}
+
+	upcasted, err := UpcastEvent(event)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var detail OrderCreatedEvent
+	if err := json.Unmarshal(upcasted.Data, &detail); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if detail.Currency != "USD" {
+		t.Errorf("expected currency USD, got %s", detail.Currency)
+	}
+	if len(detail.Items) != 2 || detail.Items[0] != (OrderLineItem{SKU: "sku-1", Quantity: 2, UnitPriceMinor: 1250}) {
+		t.Errorf("expected the original line items, got %+v", detail.Items)
+	}
+
+	order, err := LoadFromHistory([]*Event{upcasted})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.Total != (Money{Amount: 3000, Currency: "USD"}) || len(order.Items) != 2 {
+		t.Errorf("expected two items totalling 30.00 USD, got %v totalling %s", order.Items, order.Total)
+	}
+}
+
+func TestUpcastCurrentVersionIsNoop(t *testing.T) {
+	order, err := NewOrder("order-123", "customer-456", []LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: Money{Amount: 100, Currency: "USD"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	event := NewOrderCreatedEvent("event-1", "corr-1", order)
+
+	upcasted, err := UpcastEvent(event)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if upcasted != event {
+		t.Errorf("expected v2 event to pass through unchanged")
+	}
+}
+
+func TestUpcasterRegistryDetectsLoops(t *testing.T) {
+	registry := NewUpcasterRegistry()
+	registry.Register("Looping", "1.0", func(event *Event) (*Event, error) {
+		next := *event
+		next.Version = "2.0"
+		return &next, nil
+	})
+	registry.Register("Looping", "2.0", func(event *Event) (*Event, error) {
+		next := *event
+		next.Version = "1.0"
+		return &next, nil
+	})
+
+	if _, err := registry.Upcast(&Event{EventType: "Looping", Version: "1.0"}); !errors.Is(err, ErrUpcastLoop) {
+		t.Errorf("expected ErrUpcastLoop, got %v", err)
+	}
+}
@@ -8,13 +8,17 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 )
 
+type CloudWatchAPI interface {
+	PutMetricData(ctx context.Context, params *cloudwatch.PutMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutMetricDataOutput, error)
+}
+
 type Metrics struct {
-	client    *cloudwatch.Client
+	client    CloudWatchAPI
 	logger    *Logger
 	namespace string
 }
 
-func NewMetrics(client *cloudwatch.Client, logger *Logger, namespace string) *Metrics {
+func NewMetrics(client CloudWatchAPI, logger *Logger, namespace string) *Metrics {
 	return &Metrics{
 		client:    client,
 		logger:    logger,
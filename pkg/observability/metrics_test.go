@@ -0,0 +1,79 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+type fakeCloudWatch struct {
+	inputs []*cloudwatch.PutMetricDataInput
+	err    error
+}
+
+func (f *fakeCloudWatch) PutMetricData(ctx context.Context, params *cloudwatch.PutMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutMetricDataOutput, error) {
+	f.inputs = append(f.inputs, params)
+	return &cloudwatch.PutMetricDataOutput{}, f.err
+}
+
+func TestMetrics(t *testing.T) {
+	tests := []struct {
+		name      string
+		record    func(m *Metrics) error
+		wantName  string
+		wantValue float64
+		wantUnit  types.StandardUnit
+		wantDims  int
+	}{
+		{
+			name: "counter",
+			record: func(m *Metrics) error {
+				return m.IncrementCounter(context.Background(), "events_processed", map[string]string{"event_type": "OrderCreated"})
+			},
+			wantName:  "events_processed",
+			wantValue: 1,
+			wantUnit:  types.StandardUnitCount,
+			wantDims:  1,
+		},
+		{
+			name: "duration",
+			record: func(m *Metrics) error {
+				return m.RecordDuration(context.Background(), "processing_duration", 42.5, nil)
+			},
+			wantName:  "processing_duration",
+			wantValue: 42.5,
+			wantUnit:  types.StandardUnitMilliseconds,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeCloudWatch{}
+			if err := tt.record(NewMetrics(client, NewLogger("", ""), "EventPlatform")); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(client.inputs) != 1 {
+				t.Fatalf("expected one PutMetricData call, got %d", len(client.inputs))
+			}
+			input := client.inputs[0]
+			if aws.ToString(input.Namespace) != "EventPlatform" || len(input.MetricData) != 1 {
+				t.Fatalf("unexpected input: %+v", input)
+			}
+			datum := input.MetricData[0]
+			if aws.ToString(datum.MetricName) != tt.wantName || aws.ToFloat64(datum.Value) != tt.wantValue || datum.Unit != tt.wantUnit || len(datum.Dimensions) != tt.wantDims {
+				t.Errorf("unexpected datum: %+v", datum)
+			}
+		})
+	}
+}
+
+func TestMetricsReturnsClientErrors(t *testing.T) {
+	client := &fakeCloudWatch{err: errors.New("throttled")}
+	if err := NewMetrics(client, NewLogger("", ""), "EventPlatform").IncrementCounter(context.Background(), "events_processed", nil); err == nil {
+		t.Error("expected the client error to be returned")
+	}
+}
@@ -0,0 +1,69 @@
+// Package platform defines the storage and publishing contracts the use cases
+// depend on. The AWS implementations live in internal/infra; pkg/testkit
+// provides in-memory ones and pkg/testkit/conformance verifies any of them.
+package platform
+
+import (
+	"context"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+)
+
+type EventRepository interface {
+	SaveEvent(ctx context.Context, event *domain.Event, expectedVersion int64) error
+	GetEventsByOrderID(ctx context.Context, orderID domain.OrderID) ([]*domain.Event, error)
+	GetEventsAfterVersion(ctx context.Context, orderID domain.OrderID, afterVersion int64) ([]*domain.Event, error)
+}
+
+type OrderRepository interface {
+	LoadOrder(ctx context.Context, orderID domain.OrderID) (*domain.Order, error)
+}
+
+type PublishResult struct {
+	Event *domain.Event
+	Err   error
+}
+
+type EventPublisher interface {
+	PublishEvent(ctx context.Context, event *domain.Event) error
+	PublishEvents(ctx context.Context, events []*domain.Event) []PublishResult
+}
+
+// SaveOrder returns domain.ErrStaleEvent and writes nothing if the stored
+// order is at the same or a newer version. Orders with version 0 carry no
+// version information and are written unconditionally.
+type ReadModelRepository interface {
+	SaveOrder(ctx context.Context, order *domain.Order) error
+	GetOrder(ctx context.Context, orderID domain.OrderID) (*domain.Order, error)
+}
+
+type ProcessedEventsRepository interface {
+	MarkAsProcessed(ctx context.Context, eventID string) error
+	IsProcessed(ctx context.Context, eventID string) (bool, error)
+}
+
+// ProjectionRepository commits a read model write together with the
+// processed marker of the event that caused it. SaveOrderAndMarkProcessed
+// returns domain.ErrEventAlreadyProcessed and writes nothing if the marker
+// already exists.
+type ProjectionRepository interface {
+	ReadModelRepository
+	ProcessedEventsRepository
+	SaveOrderAndMarkProcessed(ctx context.Context, order *domain.Order, eventID string) error
+}
+
+type OutboxEntry struct {
+	Event    *domain.Event
+	Attempts int
+}
+
+// GetPendingEvents pages through the outbox. Pass an empty cursor to start at
+// the beginning; an empty next cursor means the outbox has been read. Entries
+// marked as failed are not returned; they stay in the outbox for inspection
+// until an operator requeues or deletes them.
+type OutboxRepository interface {
+	GetPendingEvents(ctx context.Context, cursor string, limit int) ([]*OutboxEntry, string, error)
+	MarkAsPublished(ctx context.Context, eventID string) error
+	RecordFailure(ctx context.Context, eventID string, cause error) error
+	MarkAsFailed(ctx context.Context, eventID string, cause error) error
+}
@@ -0,0 +1,27 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+)
+
+const concurrentWriters = 8
+
+// Suites may run against shared storage such as a DynamoDB Local table, so
+// every test works on ids that are unique to the run.
+func newID(prefix string) string {
+	return prefix + "-" + uuid.NewString()
+}
+
+func newOrder(t *testing.T) *domain.Order {
+	t.Helper()
+	order, err := domain.NewOrder(domain.OrderID(newID("order")), "customer-1", []domain.LineItem{
+		{SKU: "sku-1", Quantity: 2, UnitPrice: domain.Money{Amount: 1250, Currency: domain.DefaultCurrency}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return order
+}
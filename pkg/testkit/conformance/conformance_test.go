@@ -0,0 +1,57 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/platform"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/testkit"
+)
+
+func TestInMemoryRepositories(t *testing.T) {
+	t.Run("EventRepository", func(t *testing.T) {
+		RunEventRepositoryTests(t, func(t *testing.T) platform.EventRepository {
+			return infra.NewInMemoryEventRepository()
+		})
+	})
+	t.Run("ProcessedEventsRepository", func(t *testing.T) {
+		shared := infra.NewInMemoryProcessedEventsRepository("")
+		RunProcessedEventsRepositoryTests(t, func(t *testing.T, consumer string) platform.ProcessedEventsRepository {
+			return shared.ForConsumer(consumer)
+		})
+	})
+	t.Run("ReadModelRepository", func(t *testing.T) {
+		RunReadModelRepositoryTests(t, func(t *testing.T) platform.ReadModelRepository {
+			return infra.NewInMemoryReadModelRepository()
+		})
+	})
+	t.Run("ProjectionRepository", func(t *testing.T) {
+		RunProjectionRepositoryTests(t, func(t *testing.T) platform.ProjectionRepository {
+			return infra.NewInMemoryProjectionRepository(infra.NewInMemoryProcessedEventsRepository("projection"))
+		})
+	})
+}
+
+func TestTestkitRepositories(t *testing.T) {
+	t.Run("EventRepository", func(t *testing.T) {
+		RunEventRepositoryTests(t, func(t *testing.T) platform.EventRepository {
+			return testkit.NewEventRepository()
+		})
+	})
+	t.Run("ProcessedEventsRepository", func(t *testing.T) {
+		shared := testkit.NewProcessedEventsRepository()
+		RunProcessedEventsRepositoryTests(t, func(t *testing.T, consumer string) platform.ProcessedEventsRepository {
+			return shared.ForConsumer(consumer)
+		})
+	})
+	t.Run("ReadModelRepository", func(t *testing.T) {
+		RunReadModelRepositoryTests(t, func(t *testing.T) platform.ReadModelRepository {
+			return testkit.NewReadModelRepository()
+		})
+	})
+	t.Run("ProjectionRepository", func(t *testing.T) {
+		RunProjectionRepositoryTests(t, func(t *testing.T) platform.ProjectionRepository {
+			return testkit.NewProjectionRepository()
+		})
+	})
+}
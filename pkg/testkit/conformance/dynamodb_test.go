@@ -0,0 +1,117 @@
+package conformance
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/platform"
+)
+
+// Runs against DynamoDB Local, e.g.
+// docker run -p 8000:8000 amazon/dynamodb-local
+// DYNAMODB_ENDPOINT=http://localhost:8000 go test ./pkg/testkit/conformance/...
+func TestDynamoDBRepositories(t *testing.T) {
+	endpoint := os.Getenv("DYNAMODB_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("DYNAMODB_ENDPOINT not set")
+	}
+
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion("eu-central-1"),
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("local", "local", "")),
+	)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	client := dynamodb.NewFromConfig(cfg, func(o *dynamodb.Options) {
+		o.BaseEndpoint = aws.String(endpoint)
+	})
+	logger := observability.NewLoggerWithLevel("", "", observability.LogLevelError)
+
+	eventsTable := createTable(t, client, "events", "order_id", "aggregate_version")
+	outboxTable := createTable(t, client, "outbox", "event_id", "")
+	processedTable := createTable(t, client, "processed-events", "event_id", "")
+	readTable := createTable(t, client, "orders-read", "order_id", "")
+
+	t.Run("EventRepository", func(t *testing.T) {
+		RunEventRepositoryTests(t, func(t *testing.T) platform.EventRepository {
+			return infra.NewDynamoDBEventRepository(client, eventsTable, outboxTable, logger)
+		})
+	})
+	t.Run("ProcessedEventsRepository", func(t *testing.T) {
+		RunProcessedEventsRepositoryTests(t, func(t *testing.T, consumer string) platform.ProcessedEventsRepository {
+			return infra.NewDynamoDBProcessedEventsRepository(client, processedTable, consumer, logger)
+		})
+	})
+	t.Run("ReadModelRepository", func(t *testing.T) {
+		RunReadModelRepositoryTests(t, func(t *testing.T) platform.ReadModelRepository {
+			return infra.NewDynamoDBReadModelRepository(client, readTable, logger)
+		})
+	})
+	t.Run("ReadModelRepository overwrites unversioned rows", func(t *testing.T) {
+		orderID := newID("order")
+		_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+			TableName: aws.String(readTable),
+			Item: map[string]types.AttributeValue{
+				"order_id":    &types.AttributeValueMemberS{Value: orderID},
+				"customer_id": &types.AttributeValueMemberS{Value: "customer-1"},
+				"status":      &types.AttributeValueMemberS{Value: string(domain.OrderStatusCreated)},
+			},
+		})
+		if err != nil {
+			t.Fatalf("put legacy row: %v", err)
+		}
+
+		repo := infra.NewDynamoDBReadModelRepository(client, readTable, logger)
+		order := &domain.Order{ID: domain.OrderID(orderID), CustomerID: "customer-1", Status: domain.OrderStatusConfirmed, Version: 2}
+		if err := repo.SaveOrder(ctx, order); err != nil {
+			t.Fatalf("expected the versioned write to replace the legacy row, got %v", err)
+		}
+		if got, err := repo.GetOrder(ctx, order.ID); err != nil || got == nil || got.Version != 2 {
+			t.Errorf("expected the order at version 2, got %+v (%v)", got, err)
+		}
+	})
+	t.Run("ProjectionRepository", func(t *testing.T) {
+		RunProjectionRepositoryTests(t, func(t *testing.T) platform.ProjectionRepository {
+			processed := infra.NewDynamoDBProcessedEventsRepository(client, processedTable, "projection", logger)
+			return infra.NewDynamoDBProjectionRepository(client, readTable, processed, logger)
+		})
+	})
+}
+
+func createTable(t *testing.T, client *dynamodb.Client, name, hashKey, rangeKey string) string {
+	t.Helper()
+	ctx := context.Background()
+	tableName := newID("conformance-" + name)
+
+	attributes := []types.AttributeDefinition{{AttributeName: aws.String(hashKey), AttributeType: types.ScalarAttributeTypeS}}
+	keySchema := []types.KeySchemaElement{{AttributeName: aws.String(hashKey), KeyType: types.KeyTypeHash}}
+	if rangeKey != "" {
+		attributes = append(attributes, types.AttributeDefinition{AttributeName: aws.String(rangeKey), AttributeType: types.ScalarAttributeTypeN})
+		keySchema = append(keySchema, types.KeySchemaElement{AttributeName: aws.String(rangeKey), KeyType: types.KeyTypeRange})
+	}
+
+	_, err := client.CreateTable(ctx, &dynamodb.CreateTableInput{
+		TableName:            aws.String(tableName),
+		AttributeDefinitions: attributes,
+		KeySchema:            keySchema,
+		BillingMode:          types.BillingModePayPerRequest,
+	})
+	if err != nil {
+		t.Fatalf("create table %s: %v", tableName, err)
+	}
+	t.Cleanup(func() {
+		client.DeleteTable(context.Background(), &dynamodb.DeleteTableInput{TableName: aws.String(tableName)})
+	})
+	return tableName
+}
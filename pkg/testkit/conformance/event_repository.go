@@ -0,0 +1,268 @@
+package conformance
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/platform"
+)
+
+func RunEventRepositoryTests(t *testing.T, newRepo func(t *testing.T) platform.EventRepository) {
+	t.Run("appends and reads a stream in version order", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t)
+		order := newOrder(t)
+
+		created := domain.NewOrderCreatedEvent(newID("event"), "corr-1", order)
+		confirmed, err := order.Confirm(newID("event"), "corr-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		shipped, err := order.Ship(newID("event"), "corr-1", "TRACK-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for i, event := range []*domain.Event{created, confirmed, shipped} {
+			if err := repo.SaveEvent(ctx, event, int64(i)); err != nil {
+				t.Fatalf("save version %d: %v", i+1, err)
+			}
+		}
+
+		events, err := repo.GetEventsByOrderID(ctx, order.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{created.EventID, confirmed.EventID, shipped.EventID}
+		if len(events) != len(want) {
+			t.Fatalf("expected %d events, got %d", len(want), len(events))
+		}
+		for i, event := range events {
+			if event.EventID != want[i] || event.AggregateVersion != int64(i+1) {
+				t.Errorf("event %d: expected %s at version %d, got %s at version %d", i, want[i], i+1, event.EventID, event.AggregateVersion)
+			}
+			if event.OrderID != order.ID || event.CorrelationID != "corr-1" {
+				t.Errorf("event %d: metadata was not preserved: %+v", i, event)
+			}
+		}
+		if events[0].EventType != domain.EventTypeOrderCreated || events[2].EventType != domain.EventTypeOrderShipped {
+			t.Errorf("event types were not preserved")
+		}
+
+		rebuilt, err := domain.LoadFromHistory(events)
+		if err != nil {
+			t.Fatalf("stored events do not replay: %v", err)
+		}
+		if rebuilt.Status != domain.OrderStatusShipped || rebuilt.Total != order.Total {
+			t.Errorf("expected a shipped order totalling %v, got %s totalling %v", order.Total, rebuilt.Status, rebuilt.Total)
+		}
+	})
+
+	t.Run("reads only events after a version", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t)
+		order := newOrder(t)
+
+		if err := repo.SaveEvent(ctx, domain.NewOrderCreatedEvent(newID("event"), "corr-1", order), 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		confirmed, _ := order.Confirm(newID("event"), "corr-1")
+		if err := repo.SaveEvent(ctx, confirmed, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		tests := []struct {
+			afterVersion int64
+			wantCount    int
+		}{
+			{afterVersion: 0, wantCount: 2},
+			{afterVersion: 1, wantCount: 1},
+			{afterVersion: 2, wantCount: 0},
+			{afterVersion: 10, wantCount: 0},
+		}
+		for _, tt := range tests {
+			events, err := repo.GetEventsAfterVersion(ctx, order.ID, tt.afterVersion)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(events) != tt.wantCount {
+				t.Errorf("after version %d: expected %d events, got %d", tt.afterVersion, tt.wantCount, len(events))
+			}
+			for _, event := range events {
+				if event.AggregateVersion <= tt.afterVersion {
+					t.Errorf("after version %d: got event at version %d", tt.afterVersion, event.AggregateVersion)
+				}
+			}
+		}
+	})
+
+	t.Run("unknown order has an empty stream", func(t *testing.T) {
+		events, err := newRepo(t).GetEventsByOrderID(context.Background(), domain.OrderID(newID("missing")))
+		if err != nil {
+			t.Fatalf("expected no error for an unknown order, got %v", err)
+		}
+		if len(events) != 0 {
+			t.Errorf("expected no events, got %d", len(events))
+		}
+	})
+
+	t.Run("streams are isolated per order", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t)
+		first, second := newOrder(t), newOrder(t)
+
+		if err := repo.SaveEvent(ctx, domain.NewOrderCreatedEvent(newID("event"), "corr-1", first), 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.SaveEvent(ctx, domain.NewOrderCreatedEvent(newID("event"), "corr-2", second), 0); err != nil {
+			t.Fatalf("a second order must not conflict with the first: %v", err)
+		}
+
+		events, err := repo.GetEventsByOrderID(ctx, second.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(events) != 1 || events[0].OrderID != second.ID {
+			t.Errorf("expected only the second order's event, got %d events", len(events))
+		}
+	})
+
+	t.Run("rejects a duplicate order", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t)
+		order := newOrder(t)
+
+		if err := repo.SaveEvent(ctx, domain.NewOrderCreatedEvent(newID("event"), "corr-1", order), 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		err := repo.SaveEvent(ctx, domain.NewOrderCreatedEvent(newID("event"), "corr-2", order), 0)
+		if !errors.Is(err, domain.ErrOrderAlreadyExists) {
+			t.Errorf("expected ErrOrderAlreadyExists, got %v", err)
+		}
+	})
+
+	t.Run("rejects a stale expected version", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t)
+		order := newOrder(t)
+
+		if err := repo.SaveEvent(ctx, domain.NewOrderCreatedEvent(newID("event"), "corr-1", order), 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		stale := *order
+		confirmed, _ := order.Confirm(newID("event"), "corr-1")
+		if err := repo.SaveEvent(ctx, confirmed, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		cancelled, err := stale.Cancel(newID("event"), "corr-2", "changed my mind")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		err = repo.SaveEvent(ctx, cancelled, 1)
+		var concurrencyErr *domain.ConcurrencyError
+		if !errors.As(err, &concurrencyErr) || !errors.Is(err, domain.ErrConcurrencyConflict) {
+			t.Fatalf("expected a ConcurrencyError, got %v", err)
+		}
+		if concurrencyErr.OrderID != order.ID || concurrencyErr.ExpectedVersion != 1 {
+			t.Errorf("unexpected conflict details: %+v", concurrencyErr)
+		}
+
+		events, _ := repo.GetEventsByOrderID(ctx, order.ID)
+		if len(events) != 2 || events[1].EventID != confirmed.EventID {
+			t.Errorf("the rejected event must not be stored")
+		}
+	})
+
+	t.Run("rejects an event that does not follow the expected version", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t)
+		order := newOrder(t)
+
+		if err := repo.SaveEvent(ctx, domain.NewOrderCreatedEvent(newID("event"), "corr-1", order), 1); err == nil {
+			t.Fatal("expected an error for a version 1 event saved after version 1")
+		}
+		events, _ := repo.GetEventsByOrderID(ctx, order.ID)
+		if len(events) != 0 {
+			t.Errorf("the rejected event must not be stored")
+		}
+	})
+
+	t.Run("lets exactly one concurrent writer win", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t)
+		order := newOrder(t)
+
+		if err := repo.SaveEvent(ctx, domain.NewOrderCreatedEvent(newID("event"), "corr-1", order), 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		errs := make([]error, concurrentWriters)
+		for i := range errs {
+			writer := *order
+			event, err := writer.Confirm(newID("event"), "corr-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = repo.SaveEvent(ctx, event, 1)
+			}(i)
+		}
+		wg.Wait()
+
+		wins := 0
+		for _, err := range errs {
+			switch {
+			case err == nil:
+				wins++
+			case !errors.Is(err, domain.ErrConcurrencyConflict):
+				t.Errorf("expected a concurrency conflict for losing writers, got %v", err)
+			}
+		}
+		if wins != 1 {
+			t.Errorf("expected exactly one winning writer, got %d", wins)
+		}
+
+		events, _ := repo.GetEventsByOrderID(ctx, order.ID)
+		if len(events) != 2 {
+			t.Errorf("expected 2 stored events, got %d", len(events))
+		}
+	})
+
+	t.Run("lets exactly one concurrent creator win", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t)
+		order := newOrder(t)
+
+		var wg sync.WaitGroup
+		errs := make([]error, concurrentWriters)
+		for i := range errs {
+			event := domain.NewOrderCreatedEvent(newID("event"), "corr-1", order)
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = repo.SaveEvent(ctx, event, 0)
+			}(i)
+		}
+		wg.Wait()
+
+		wins := 0
+		for _, err := range errs {
+			switch {
+			case err == nil:
+				wins++
+			case !errors.Is(err, domain.ErrOrderAlreadyExists):
+				t.Errorf("expected ErrOrderAlreadyExists for losing creators, got %v", err)
+			}
+		}
+		if wins != 1 {
+			t.Errorf("expected exactly one winning creator, got %d", wins)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package conformance
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/platform"
+)
+
+// Repositories returned by newRepo for different consumers must share storage,
+// so that the suite can check that their markers do not collide.
+func RunProcessedEventsRepositoryTests(t *testing.T, newRepo func(t *testing.T, consumer string) platform.ProcessedEventsRepository) {
+	t.Run("unknown event is not processed", func(t *testing.T) {
+		processed, err := newRepo(t, "projection").IsProcessed(context.Background(), newID("event"))
+		if err != nil {
+			t.Fatalf("expected no error for an unknown event, got %v", err)
+		}
+		if processed {
+			t.Error("expected an unknown event to be unprocessed")
+		}
+	})
+
+	t.Run("marks an event as processed", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t, "projection")
+		eventID, other := newID("event"), newID("event")
+
+		if err := repo.MarkAsProcessed(ctx, eventID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if processed, err := repo.IsProcessed(ctx, eventID); err != nil || !processed {
+			t.Errorf("expected %s to be processed, got %v, %v", eventID, processed, err)
+		}
+		if processed, err := repo.IsProcessed(ctx, other); err != nil || processed {
+			t.Errorf("expected %s to stay unprocessed, got %v, %v", other, processed, err)
+		}
+	})
+
+	t.Run("marking twice is idempotent", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t, "projection")
+		eventID := newID("event")
+
+		for i := 0; i < 2; i++ {
+			if err := repo.MarkAsProcessed(ctx, eventID); err != nil {
+				t.Fatalf("mark %d: expected no error, got %v", i+1, err)
+			}
+		}
+		if processed, err := repo.IsProcessed(ctx, eventID); err != nil || !processed {
+			t.Errorf("expected %s to be processed, got %v, %v", eventID, processed, err)
+		}
+	})
+
+	t.Run("concurrent marks of the same event all succeed", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t, "projection")
+		eventID := newID("event")
+
+		var wg sync.WaitGroup
+		errs := make([]error, concurrentWriters)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = repo.MarkAsProcessed(ctx, eventID)
+			}(i)
+		}
+		wg.Wait()
+
+		for i, err := range errs {
+			if err != nil {
+				t.Errorf("writer %d: expected no error, got %v", i, err)
+			}
+		}
+		if processed, err := repo.IsProcessed(ctx, eventID); err != nil || !processed {
+			t.Errorf("expected %s to be processed, got %v, %v", eventID, processed, err)
+		}
+	})
+
+	t.Run("markers are scoped per consumer", func(t *testing.T) {
+		ctx := context.Background()
+		projection, notifier := newRepo(t, "projection"), newRepo(t, "notifier")
+		eventID := newID("event")
+
+		if err := projection.MarkAsProcessed(ctx, eventID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if processed, err := notifier.IsProcessed(ctx, eventID); err != nil || processed {
+			t.Errorf("expected %s to stay unprocessed for another consumer, got %v, %v", eventID, processed, err)
+		}
+		if err := notifier.MarkAsProcessed(ctx, eventID); err != nil {
+			t.Fatalf("expected a second consumer to mark the event, got %v", err)
+		}
+		for name, repo := range map[string]platform.ProcessedEventsRepository{"projection": projection, "notifier": notifier} {
+			if processed, err := repo.IsProcessed(ctx, eventID); err != nil || !processed {
+				t.Errorf("expected %s to be processed for %s, got %v, %v", eventID, name, processed, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package conformance
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/platform"
+)
+
+func RunProjectionRepositoryTests(t *testing.T, newRepo func(t *testing.T) platform.ProjectionRepository) {
+	t.Run("saves the order and marks the event", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t)
+		order := newOrder(t)
+		eventID := newID("event")
+
+		if err := repo.SaveOrderAndMarkProcessed(ctx, order, eventID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got, err := repo.GetOrder(ctx, order.ID); err != nil || got == nil || got.Version != order.Version {
+			t.Errorf("expected the order in the read model, got %+v, %v", got, err)
+		}
+		if processed, err := repo.IsProcessed(ctx, eventID); err != nil || !processed {
+			t.Errorf("expected %s to be processed, got %v, %v", eventID, processed, err)
+		}
+	})
+
+	t.Run("a processed event writes nothing", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t)
+		order := newOrder(t)
+		eventID := newID("event")
+
+		if err := repo.SaveOrderAndMarkProcessed(ctx, order, eventID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		replayed := *order
+		replayed.Status = domain.OrderStatusCancelled
+		replayed.Version = 7
+
+		err := repo.SaveOrderAndMarkProcessed(ctx, &replayed, eventID)
+		if !errors.Is(err, domain.ErrEventAlreadyProcessed) {
+			t.Fatalf("expected ErrEventAlreadyProcessed, got %v", err)
+		}
+		if got, _ := repo.GetOrder(ctx, order.ID); got == nil || got.Status != order.Status || got.Version != order.Version {
+			t.Errorf("the duplicate delivery must not change the read model, got %+v", got)
+		}
+	})
+
+	t.Run("an event marked on its own is not applied", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t)
+		order := newOrder(t)
+		eventID := newID("event")
+
+		if err := repo.MarkAsProcessed(ctx, eventID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.SaveOrderAndMarkProcessed(ctx, order, eventID); !errors.Is(err, domain.ErrEventAlreadyProcessed) {
+			t.Fatalf("expected ErrEventAlreadyProcessed, got %v", err)
+		}
+		if got, err := repo.GetOrder(ctx, order.ID); err != nil || got != nil {
+			t.Errorf("expected no order in the read model, got %+v, %v", got, err)
+		}
+	})
+
+	t.Run("concurrent deliveries apply the event once", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t)
+		order := newOrder(t)
+		eventID := newID("event")
+
+		var wg sync.WaitGroup
+		errs := make([]error, concurrentWriters)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = repo.SaveOrderAndMarkProcessed(ctx, order, eventID)
+			}(i)
+		}
+		wg.Wait()
+
+		applied := 0
+		for _, err := range errs {
+			switch {
+			case err == nil:
+				applied++
+			case !errors.Is(err, domain.ErrEventAlreadyProcessed):
+				t.Errorf("expected ErrEventAlreadyProcessed for duplicate deliveries, got %v", err)
+			}
+		}
+		if applied != 1 {
+			t.Errorf("expected the event to be applied exactly once, got %d", applied)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package conformance
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/platform"
+)
+
+func RunReadModelRepositoryTests(t *testing.T, newRepo func(t *testing.T) platform.ReadModelRepository) {
+	t.Run("unknown order returns nil without an error", func(t *testing.T) {
+		order, err := newRepo(t).GetOrder(context.Background(), domain.OrderID(newID("missing")))
+		if err != nil {
+			t.Fatalf("expected no error for an unknown order, got %v", err)
+		}
+		if order != nil {
+			t.Errorf("expected nil order, got %+v", order)
+		}
+	})
+
+	t.Run("round-trips an order", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t)
+		order := newOrder(t)
+
+		if err := repo.SaveOrder(ctx, order); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, err := repo.GetOrder(ctx, order.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got == nil {
+			t.Fatal("expected the saved order")
+		}
+		if got.ID != order.ID || got.CustomerID != order.CustomerID || got.Status != order.Status || got.Version != order.Version {
+			t.Errorf("expected %+v, got %+v", order, got)
+		}
+		if got.Total != order.Total || len(got.Items) != len(order.Items) || got.Items[0] != order.Items[0] {
+			t.Errorf("expected items %v totalling %v, got %v totalling %v", order.Items, order.Total, got.Items, got.Total)
+		}
+	})
+
+	t.Run("saving again replaces the order", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t)
+		order := newOrder(t)
+
+		if err := repo.SaveOrder(ctx, order); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := order.Confirm(newID("event"), "corr-1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.SaveOrder(ctx, order); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got, err := repo.GetOrder(ctx, order.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got == nil || got.Status != domain.OrderStatusConfirmed || got.Version != 2 {
+			t.Errorf("expected a confirmed order at version 2, got %+v", got)
+		}
+	})
+
+	t.Run("a versioned write replaces an unversioned order", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t)
+		order := newOrder(t)
+		unversioned := *order
+		unversioned.Version = 0
+
+		if err := repo.SaveOrder(ctx, &unversioned); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.SaveOrder(ctx, order); err != nil {
+			t.Fatalf("expected version %d to replace the unversioned order, got %v", order.Version, err)
+		}
+	})
+
+	t.Run("rejects a stale version", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepo(t)
+		order := newOrder(t)
+		stale := *order
+
+		if _, err := order.Confirm(newID("event"), "corr-1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.SaveOrder(ctx, order); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, write := range []*domain.Order{&stale, order} {
+			if err := repo.SaveOrder(ctx, write); !errors.Is(err, domain.ErrStaleEvent) {
+				t.Errorf("saving version %d over version 2: expected %v, got %v", write.Version, domain.ErrStaleEvent, err)
+			}
+		}
+
+		got, err := repo.GetOrder(ctx, order.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got == nil || got.Status != domain.OrderStatusConfirmed || got.Version != 2 {
+			t.Errorf("expected the confirmed order to survive, got %+v", got)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package testkit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/platform"
+)
+
+// EventPublisher records every successfully published event. Failures can be
+// injected for all calls or for individual event IDs.
+type EventPublisher struct {
+	recorder
+	published     []*domain.Event
+	eventFailures map[string][]*injectedFailure
+}
+
+func NewEventPublisher() *EventPublisher {
+	return &EventPublisher{
+		recorder:      newRecorder(),
+		eventFailures: make(map[string][]*injectedFailure),
+	}
+}
+
+func (p *EventPublisher) InjectEventFailure(eventID string, err error, times int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.eventFailures[eventID] = append(p.eventFailures[eventID], &injectedFailure{err: err, remaining: times})
+}
+
+func (p *EventPublisher) PublishEvent(ctx context.Context, event *domain.Event) error {
+	if err := p.record(MethodPublishEvent); err != nil {
+		return err
+	}
+	return p.publish(event)
+}
+
+func (p *EventPublisher) PublishEvents(ctx context.Context, events []*domain.Event) []platform.PublishResult {
+	results := make([]platform.PublishResult, len(events))
+	callErr := p.record(MethodPublishEvents)
+	for i, event := range events {
+		results[i].Event = event
+		if callErr != nil {
+			results[i].Err = callErr
+			continue
+		}
+		results[i].Err = p.publish(event)
+	}
+	return results
+}
+
+func (p *EventPublisher) publish(event *domain.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := takeFailure(p.eventFailures, event.EventID); err != nil {
+		return err
+	}
+	copied := *event
+	p.published = append(p.published, &copied)
+	return nil
+}
+
+func (p *EventPublisher) Published() []*domain.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*domain.Event(nil), p.published...)
+}
+
+func (p *EventPublisher) PublishedOfType(eventType string) []*domain.Event {
+	var events []*domain.Event
+	for _, event := range p.Published() {
+		if event.EventType == eventType {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func (p *EventPublisher) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.published = nil
+	p.calls = make(map[string]int)
+}
+
+func (p *EventPublisher) AssertPublishedTypes(t testing.TB, eventTypes ...string) {
+	t.Helper()
+	published := p.Published()
+	got := make([]string, len(published))
+	for i, event := range published {
+		got[i] = event.EventType
+	}
+	if fmt.Sprint(got) != fmt.Sprint(eventTypes) {
+		t.Errorf("expected published event types %v, got %v", eventTypes, got)
+	}
+}
+
+func (p *EventPublisher) AssertPublishedCount(t testing.TB, want int) {
+	t.Helper()
+	if got := len(p.Published()); got != want {
+		t.Errorf("expected %d published events, got %d", want, got)
+	}
+}
+
+func (p *EventPublisher) AssertNothingPublished(t testing.TB) {
+	t.Helper()
+	p.AssertPublishedCount(t, 0)
+}
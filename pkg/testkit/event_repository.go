@@ -0,0 +1,107 @@
+package testkit
+
+import (
+	"context"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/platform"
+)
+
+// EventRepository is an in-memory event store with its transactional outbox.
+// It implements platform.EventRepository and platform.OutboxRepository.
+type EventRepository struct {
+	recorder
+	store *infra.InMemoryEventRepository
+	saved []*domain.Event
+}
+
+func NewEventRepository() *EventRepository {
+	return &EventRepository{
+		recorder: newRecorder(),
+		store:    infra.NewInMemoryEventRepository(),
+	}
+}
+
+func (r *EventRepository) SaveEvent(ctx context.Context, event *domain.Event, expectedVersion int64) error {
+	if err := r.record(MethodSaveEvent); err != nil {
+		return err
+	}
+	if err := r.store.SaveEvent(ctx, event, expectedVersion); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *event
+	r.saved = append(r.saved, &copied)
+	return nil
+}
+
+func (r *EventRepository) GetEventsByOrderID(ctx context.Context, orderID domain.OrderID) ([]*domain.Event, error) {
+	if err := r.record(MethodGetEventsByOrderID); err != nil {
+		return nil, err
+	}
+	return r.store.GetEventsByOrderID(ctx, orderID)
+}
+
+func (r *EventRepository) GetEventsAfterVersion(ctx context.Context, orderID domain.OrderID, afterVersion int64) ([]*domain.Event, error) {
+	if err := r.record(MethodGetEventsAfterVersion); err != nil {
+		return nil, err
+	}
+	return r.store.GetEventsAfterVersion(ctx, orderID, afterVersion)
+}
+
+func (r *EventRepository) GetPendingEvents(ctx context.Context, cursor string, limit int) ([]*platform.OutboxEntry, string, error) {
+	if err := r.record(MethodGetPendingEvents); err != nil {
+		return nil, "", err
+	}
+	return r.store.GetPendingEvents(ctx, cursor, limit)
+}
+
+func (r *EventRepository) MarkAsPublished(ctx context.Context, eventID string) error {
+	if err := r.record(MethodMarkAsPublished); err != nil {
+		return err
+	}
+	return r.store.MarkAsPublished(ctx, eventID)
+}
+
+func (r *EventRepository) RecordFailure(ctx context.Context, eventID string, cause error) error {
+	if err := r.record(MethodRecordFailure); err != nil {
+		return err
+	}
+	return r.store.RecordFailure(ctx, eventID, cause)
+}
+
+func (r *EventRepository) MarkAsFailed(ctx context.Context, eventID string, cause error) error {
+	if err := r.record(MethodMarkAsFailed); err != nil {
+		return err
+	}
+	return r.store.MarkAsFailed(ctx, eventID, cause)
+}
+
+// Seed appends events to their streams without recording a call, e.g. to
+// prepare history for a command under test.
+func (r *EventRepository) Seed(ctx context.Context, events ...*domain.Event) error {
+	for _, event := range events {
+		if err := r.store.SaveEvent(ctx, event, event.AggregateVersion-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *EventRepository) SavedEvents() []*domain.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*domain.Event(nil), r.saved...)
+}
+
+func (r *EventRepository) PendingOutbox(ctx context.Context) []*platform.OutboxEntry {
+	entries, _, _ := r.store.GetPendingEvents(ctx, "", int(^uint(0)>>1))
+	return entries
+}
+
+func (r *EventRepository) FailedOutbox(ctx context.Context) []*platform.OutboxEntry {
+	return r.store.GetFailedEvents(ctx)
+}
@@ -0,0 +1,58 @@
+package testkit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/platform"
+)
+
+type ProcessedEventsRepository struct {
+	recorder
+	store *infra.InMemoryProcessedEventsRepository
+}
+
+func NewProcessedEventsRepository() *ProcessedEventsRepository {
+	return &ProcessedEventsRepository{
+		recorder: newRecorder(),
+		store:    infra.NewInMemoryProcessedEventsRepository(Consumer),
+	}
+}
+
+// ForConsumer returns a double for another consumer that shares this one's
+// storage but records its own calls.
+func (r *ProcessedEventsRepository) ForConsumer(consumer string) *ProcessedEventsRepository {
+	return &ProcessedEventsRepository{
+		recorder: newRecorder(),
+		store:    r.store.ForConsumer(consumer),
+	}
+}
+
+func (r *ProcessedEventsRepository) MarkAsProcessed(ctx context.Context, eventID string) error {
+	if err := r.record(MethodMarkAsProcessed); err != nil {
+		return err
+	}
+	return r.store.MarkAsProcessed(ctx, eventID)
+}
+
+func (r *ProcessedEventsRepository) IsProcessed(ctx context.Context, eventID string) (bool, error) {
+	if err := r.record(MethodIsProcessed); err != nil {
+		return false, err
+	}
+	return r.store.IsProcessed(ctx, eventID)
+}
+
+func (r *ProcessedEventsRepository) AssertProcessed(t testing.TB, eventIDs ...string) {
+	t.Helper()
+	assertProcessed(t, r.store, eventIDs)
+}
+
+func assertProcessed(t testing.TB, store platform.ProcessedEventsRepository, eventIDs []string) {
+	t.Helper()
+	for _, eventID := range eventIDs {
+		if processed, _ := store.IsProcessed(context.Background(), eventID); !processed {
+			t.Errorf("expected event %s to be marked as processed", eventID)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package testkit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+)
+
+// ProjectionRepository is an in-memory read model and processed-event store
+// that commits SaveOrderAndMarkProcessed atomically.
+type ProjectionRepository struct {
+	recorder
+	store *infra.InMemoryProjectionRepository
+}
+
+func NewProjectionRepository() *ProjectionRepository {
+	return &ProjectionRepository{
+		recorder: newRecorder(),
+		store:    infra.NewInMemoryProjectionRepository(infra.NewInMemoryProcessedEventsRepository(Consumer)),
+	}
+}
+
+func (r *ProjectionRepository) SaveOrder(ctx context.Context, order *domain.Order) error {
+	if err := r.record(MethodSaveOrder); err != nil {
+		return err
+	}
+	return r.store.SaveOrder(ctx, order)
+}
+
+func (r *ProjectionRepository) GetOrder(ctx context.Context, orderID domain.OrderID) (*domain.Order, error) {
+	if err := r.record(MethodGetOrder); err != nil {
+		return nil, err
+	}
+	return r.store.GetOrder(ctx, orderID)
+}
+
+func (r *ProjectionRepository) MarkAsProcessed(ctx context.Context, eventID string) error {
+	if err := r.record(MethodMarkAsProcessed); err != nil {
+		return err
+	}
+	return r.store.MarkAsProcessed(ctx, eventID)
+}
+
+func (r *ProjectionRepository) IsProcessed(ctx context.Context, eventID string) (bool, error) {
+	if err := r.record(MethodIsProcessed); err != nil {
+		return false, err
+	}
+	return r.store.IsProcessed(ctx, eventID)
+}
+
+func (r *ProjectionRepository) SaveOrderAndMarkProcessed(ctx context.Context, order *domain.Order, eventID string) error {
+	if err := r.record(MethodSaveOrderAndMarkProcessed); err != nil {
+		return err
+	}
+	return r.store.SaveOrderAndMarkProcessed(ctx, order, eventID)
+}
+
+func (r *ProjectionRepository) AssertOrderStatus(t testing.TB, orderID domain.OrderID, want domain.OrderStatus) {
+	t.Helper()
+	assertOrderStatus(t, r.store, orderID, want)
+}
+
+func (r *ProjectionRepository) AssertProcessed(t testing.TB, eventIDs ...string) {
+	t.Helper()
+	assertProcessed(t, r.store, eventIDs)
+}
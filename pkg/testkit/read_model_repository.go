@@ -0,0 +1,53 @@
+package testkit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/platform"
+)
+
+type ReadModelRepository struct {
+	recorder
+	store *infra.InMemoryReadModelRepository
+}
+
+func NewReadModelRepository() *ReadModelRepository {
+	return &ReadModelRepository{
+		recorder: newRecorder(),
+		store:    infra.NewInMemoryReadModelRepository(),
+	}
+}
+
+func (r *ReadModelRepository) SaveOrder(ctx context.Context, order *domain.Order) error {
+	if err := r.record(MethodSaveOrder); err != nil {
+		return err
+	}
+	return r.store.SaveOrder(ctx, order)
+}
+
+func (r *ReadModelRepository) GetOrder(ctx context.Context, orderID domain.OrderID) (*domain.Order, error) {
+	if err := r.record(MethodGetOrder); err != nil {
+		return nil, err
+	}
+	return r.store.GetOrder(ctx, orderID)
+}
+
+func (r *ReadModelRepository) AssertOrderStatus(t testing.TB, orderID domain.OrderID, want domain.OrderStatus) {
+	t.Helper()
+	assertOrderStatus(t, r.store, orderID, want)
+}
+
+func assertOrderStatus(t testing.TB, store platform.ReadModelRepository, orderID domain.OrderID, want domain.OrderStatus) {
+	t.Helper()
+	order, _ := store.GetOrder(context.Background(), orderID)
+	if order == nil {
+		t.Errorf("expected order %s in the read model", orderID)
+		return
+	}
+	if order.Status != want {
+		t.Errorf("expected order %s to be %s, got %s", orderID, want, order.Status)
+	}
+}
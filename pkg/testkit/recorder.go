@@ -0,0 +1,91 @@
+package testkit
+
+import (
+	"sync"
+	"testing"
+)
+
+const (
+	MethodSaveEvent                 = "SaveEvent"
+	MethodGetEventsByOrderID        = "GetEventsByOrderID"
+	MethodGetEventsAfterVersion     = "GetEventsAfterVersion"
+	MethodGetPendingEvents          = "GetPendingEvents"
+	MethodMarkAsPublished           = "MarkAsPublished"
+	MethodRecordFailure             = "RecordFailure"
+	MethodMarkAsFailed              = "MarkAsFailed"
+	MethodPublishEvent              = "PublishEvent"
+	MethodPublishEvents             = "PublishEvents"
+	MethodSaveOrder                 = "SaveOrder"
+	MethodGetOrder                  = "GetOrder"
+	MethodMarkAsProcessed           = "MarkAsProcessed"
+	MethodIsProcessed               = "IsProcessed"
+	MethodSaveOrderAndMarkProcessed = "SaveOrderAndMarkProcessed"
+)
+
+type injectedFailure struct {
+	err       error
+	remaining int
+}
+
+// recorder counts calls per method and hands out injected failures. A failure
+// injected with times <= 0 applies to every following call.
+type recorder struct {
+	mu       sync.Mutex
+	calls    map[string]int
+	failures map[string][]*injectedFailure
+}
+
+func newRecorder() recorder {
+	return recorder{
+		calls:    make(map[string]int),
+		failures: make(map[string][]*injectedFailure),
+	}
+}
+
+func (r *recorder) InjectFailure(method string, err error, times int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures[method] = append(r.failures[method], &injectedFailure{err: err, remaining: times})
+}
+
+func (r *recorder) ClearFailures() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures = make(map[string][]*injectedFailure)
+}
+
+func (r *recorder) CallCount(method string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls[method]
+}
+
+func (r *recorder) AssertCallCount(t testing.TB, method string, want int) {
+	t.Helper()
+	if got := r.CallCount(method); got != want {
+		t.Errorf("expected %d calls to %s, got %d", want, method, got)
+	}
+}
+
+func (r *recorder) record(method string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls[method]++
+	return takeFailure(r.failures, method)
+}
+
+func takeFailure(failures map[string][]*injectedFailure, key string) error {
+	queue := failures[key]
+	if len(queue) == 0 {
+		return nil
+	}
+
+	failure := queue[0]
+	if failure.remaining > 0 {
+		failure.remaining--
+		if failure.remaining == 0 {
+			failures[key] = queue[1:]
+		}
+	}
+	return failure.err
+}
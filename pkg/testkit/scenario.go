@@ -0,0 +1,225 @@
+package testkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stevenbode/go-serverless-event-platform/internal/infra"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/observability"
+	"github.com/stevenbode/go-serverless-event-platform/pkg/platform"
+)
+
+// Generated ids and timestamps differ on every run, so they are left out
+// when events are compared.
+var volatilePayloadFields = map[string]bool{
+	"event_id":    true,
+	"created_at":  true,
+	"occurred_at": true,
+}
+
+// Scenario runs a command against an in-memory event store in
+// Given/When/Then form:
+//
+//	s := testkit.NewScenario(t)
+//	uc := app.NewConfirmOrderUseCase(s.EventRepository(), s.OrderRepository(), s.Logger(), nil)
+//	s.Given(created).
+//		When(func(ctx context.Context) error { _, err := uc.Execute(ctx, req, "corr-1"); return err }).
+//		Then(confirmed)
+type Scenario struct {
+	t      testing.TB
+	ctx    context.Context
+	events *EventRepository
+	orders *infra.EventSourcedOrderRepository
+	logger *observability.Logger
+	err    error
+	ran    bool
+}
+
+func NewScenario(t testing.TB) *Scenario {
+	events := NewEventRepository()
+	logger := observability.NewLoggerWithLevel("", "", observability.LogLevelError)
+	return &Scenario{
+		t:      t,
+		ctx:    context.Background(),
+		events: events,
+		orders: infra.NewEventSourcedOrderRepository(events, logger),
+		logger: logger,
+	}
+}
+
+func (s *Scenario) EventRepository() *EventRepository {
+	return s.events
+}
+
+func (s *Scenario) OrderRepository() platform.OrderRepository {
+	return s.orders
+}
+
+func (s *Scenario) Logger() *observability.Logger {
+	return s.logger
+}
+
+// Given seeds prior events. They are not reported as new events by Then.
+func (s *Scenario) Given(events ...*domain.Event) *Scenario {
+	s.t.Helper()
+	if s.ran {
+		s.t.Fatal("Given must be called before When")
+	}
+	if err := s.events.Seed(s.ctx, events...); err != nil {
+		s.t.Fatalf("given: %v", err)
+	}
+	return s
+}
+
+func (s *Scenario) When(command func(ctx context.Context) error) *Scenario {
+	s.t.Helper()
+	if s.ran {
+		s.t.Fatal("When must be called once per scenario")
+	}
+	s.ran = true
+	s.err = command(s.ctx)
+	return s
+}
+
+// Then expects the command to succeed and to have saved exactly the given
+// events, in order. Call it without arguments to expect no new events.
+func (s *Scenario) Then(expected ...*domain.Event) {
+	s.t.Helper()
+	s.requireRan()
+	if s.err != nil {
+		s.t.Fatalf("then: expected the command to succeed, got %v", s.err)
+	}
+	if diff := DiffEvents(expected, s.events.SavedEvents()); diff != "" {
+		s.t.Errorf("then: unexpected events (-want +got):\n%s", diff)
+	}
+}
+
+// ThenError expects the command to fail with expected and to have saved no
+// events. An *domain.AppError matches on status, retriability and, if set,
+// its wrapped error; any other error is matched with errors.Is.
+func (s *Scenario) ThenError(expected error) {
+	s.t.Helper()
+	s.requireRan()
+	if s.err == nil {
+		s.t.Fatalf("then: expected error %v, the command succeeded", describeError(expected))
+	}
+	if !matchesError(s.err, expected) {
+		s.t.Errorf("then: expected error %s, got %s", describeError(expected), describeError(s.err))
+	}
+	if diff := DiffEvents(nil, s.events.SavedEvents()); diff != "" {
+		s.t.Errorf("then: a failed command must not save events (-want +got):\n%s", diff)
+	}
+}
+
+func (s *Scenario) requireRan() {
+	s.t.Helper()
+	if !s.ran {
+		s.t.Fatal("Then must be called after When")
+	}
+}
+
+func matchesError(err, expected error) bool {
+	var want *domain.AppError
+	if !errors.As(expected, &want) {
+		return errors.Is(err, expected)
+	}
+	var got *domain.AppError
+	if !errors.As(err, &got) {
+		return false
+	}
+	if got.HTTPStatus != want.HTTPStatus || got.Retriable != want.Retriable {
+		return false
+	}
+	return want.Err == nil || errors.Is(got, want.Err)
+}
+
+func describeError(err error) string {
+	var appErr *domain.AppError
+	if errors.As(err, &appErr) {
+		return fmt.Sprintf("AppError{status: %d, retriable: %t, err: %v}", appErr.HTTPStatus, appErr.Retriable, appErr.Err)
+	}
+	return fmt.Sprintf("%q", err)
+}
+
+// DiffEvents compares events field by field, ignoring generated ids and
+// timestamps, and returns a readable report or "" if they match.
+func DiffEvents(want, got []*domain.Event) string {
+	var b strings.Builder
+	if len(want) != len(got) {
+		fmt.Fprintf(&b, "- %d events %v\n+ %d events %v\n", len(want), eventTypes(want), len(got), eventTypes(got))
+	}
+
+	for i := 0; i < len(want) && i < len(got); i++ {
+		wantFields, gotFields := eventFields(want[i]), eventFields(got[i])
+		var lines []string
+		for _, key := range unionKeys(wantFields, gotFields) {
+			w, wok := wantFields[key]
+			g, gok := gotFields[key]
+			switch {
+			case wok && !gok:
+				lines = append(lines, fmt.Sprintf("  - %s: %s", key, w))
+			case !wok && gok:
+				lines = append(lines, fmt.Sprintf("  + %s: %s", key, g))
+			case w != g:
+				lines = append(lines, fmt.Sprintf("  - %s: %s\n  + %s: %s", key, w, key, g))
+			}
+		}
+		if len(lines) > 0 {
+			fmt.Fprintf(&b, "event %d (%s):\n%s\n", i+1, want[i].EventType, strings.Join(lines, "\n"))
+		}
+	}
+	return b.String()
+}
+
+func eventFields(event *domain.Event) map[string]string {
+	fields := map[string]string{
+		"event_type":        event.EventType,
+		"source":            event.Source,
+		"version":           event.Version,
+		"order_id":          string(event.OrderID),
+		"customer_id":       string(event.CustomerID),
+		"correlation_id":    event.CorrelationID,
+		"aggregate_version": fmt.Sprint(event.AggregateVersion),
+		"total_cents":       fmt.Sprint(event.TotalCents),
+	}
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(event.Data, &payload); err != nil {
+		fields["data"] = string(event.Data)
+		return fields
+	}
+	for key, value := range payload {
+		if !volatilePayloadFields[key] {
+			fields["data."+key] = string(value)
+		}
+	}
+	return fields
+}
+
+func unionKeys(a, b map[string]string) []string {
+	keys := make([]string, 0, len(a))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func eventTypes(events []*domain.Event) []string {
+	types := make([]string, len(events))
+	for i, event := range events {
+		types[i] = event.EventType
+	}
+	return types
+}
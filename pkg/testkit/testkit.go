@@ -0,0 +1,15 @@
+package testkit
+
+import "github.com/stevenbode/go-serverless-event-platform/pkg/platform"
+
+// Consumer is the consumer name the processed-event doubles record under.
+const Consumer = "testkit"
+
+var (
+	_ platform.EventRepository           = (*EventRepository)(nil)
+	_ platform.OutboxRepository          = (*EventRepository)(nil)
+	_ platform.EventPublisher            = (*EventPublisher)(nil)
+	_ platform.ReadModelRepository       = (*ReadModelRepository)(nil)
+	_ platform.ProcessedEventsRepository = (*ProcessedEventsRepository)(nil)
+	_ platform.ProjectionRepository      = (*ProjectionRepository)(nil)
+)
@@ -0,0 +1,103 @@
+package testkit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stevenbode/go-serverless-event-platform/pkg/domain"
+)
+
+func TestInjectFailure(t *testing.T) {
+	ctx := context.Background()
+	boom := errors.New("boom")
+
+	tests := []struct {
+		name      string
+		times     int
+		calls     int
+		wantFails int
+	}{
+		{name: "once", times: 1, calls: 3, wantFails: 1},
+		{name: "twice", times: 2, calls: 3, wantFails: 2},
+		{name: "always", times: 0, calls: 3, wantFails: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewProcessedEventsRepository()
+			repo.InjectFailure(MethodMarkAsProcessed, boom, tt.times)
+
+			fails := 0
+			for i := 0; i < tt.calls; i++ {
+				if err := repo.MarkAsProcessed(ctx, "event-1"); errors.Is(err, boom) {
+					fails++
+				}
+			}
+			if fails != tt.wantFails {
+				t.Errorf("expected %d failures, got %d", tt.wantFails, fails)
+			}
+			repo.AssertCallCount(t, MethodMarkAsProcessed, tt.calls)
+		})
+	}
+}
+
+func TestEventPublisherRecordsPublishedEvents(t *testing.T) {
+	ctx := context.Background()
+	publisher := NewEventPublisher()
+	publisher.InjectEventFailure("event-2", errors.New("rejected"), 0)
+
+	results := publisher.PublishEvents(ctx, []*domain.Event{
+		{EventID: "event-1", EventType: domain.EventTypeOrderCreated},
+		{EventID: "event-2", EventType: domain.EventTypeOrderConfirmed},
+	})
+	if results[0].Err != nil || results[1].Err == nil {
+		t.Fatalf("expected only event-2 to fail, got %v and %v", results[0].Err, results[1].Err)
+	}
+	if err := publisher.PublishEvent(ctx, &domain.Event{EventID: "event-3", EventType: domain.EventTypeOrderShipped}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	publisher.AssertPublishedTypes(t, domain.EventTypeOrderCreated, domain.EventTypeOrderShipped)
+	if len(publisher.PublishedOfType(domain.EventTypeOrderShipped)) != 1 {
+		t.Errorf("expected one shipped event")
+	}
+	publisher.AssertCallCount(t, MethodPublishEvents, 1)
+	publisher.AssertCallCount(t, MethodPublishEvent, 1)
+}
+
+func TestDiffEvents(t *testing.T) {
+	order, err := domain.NewOrder("order-1", "customer-1", []domain.LineItem{{SKU: "sku-1", Quantity: 1, UnitPrice: domain.Money{Amount: 100, Currency: "EUR"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created := domain.NewOrderCreatedEvent("event-1", "corr-1", order)
+	regenerated := domain.NewOrderCreatedEvent("event-2", "corr-1", order)
+	confirmed, _ := order.Confirm("event-3", "corr-1")
+	otherCorrelation := domain.NewOrderCreatedEvent("event-1", "corr-2", order)
+
+	tests := []struct {
+		name      string
+		want, got []*domain.Event
+		wantDiff  []string
+	}{
+		{name: "generated ids are ignored", want: []*domain.Event{created}, got: []*domain.Event{regenerated}},
+		{name: "missing event", want: []*domain.Event{created, confirmed}, got: []*domain.Event{created}, wantDiff: []string{"- 2 events [OrderCreated OrderConfirmed]", "+ 1 events [OrderCreated]"}},
+		{name: "field mismatch", want: []*domain.Event{created}, got: []*domain.Event{otherCorrelation}, wantDiff: []string{"- correlation_id: corr-1", "+ correlation_id: corr-2", `- data.correlation_id: "corr-1"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := DiffEvents(tt.want, tt.got)
+			if len(tt.wantDiff) == 0 && diff != "" {
+				t.Fatalf("expected no diff, got:\n%s", diff)
+			}
+			for _, line := range tt.wantDiff {
+				if !strings.Contains(diff, line) {
+					t.Errorf("expected diff to contain %q, got:\n%s", line, diff)
+				}
+			}
+		})
+	}
+}